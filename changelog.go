@@ -0,0 +1,211 @@
+package mcmodupdater
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/mrmelon54/mcmodupdater/develop"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+)
+
+type ChangelogFormat int
+
+const (
+	ChangelogKeepAChangelog ChangelogFormat = iota
+	ChangelogPlainText
+	ChangelogTemplate
+)
+
+var changelogFormatNames = map[string]ChangelogFormat{
+	"keepachangelog": ChangelogKeepAChangelog,
+	"markdown":       ChangelogKeepAChangelog,
+	"plain":          ChangelogPlainText,
+	"text":           ChangelogPlainText,
+	"template":       ChangelogTemplate,
+}
+
+func ChangelogFormatFromName(name string) (ChangelogFormat, bool) {
+	a, ok := changelogFormatNames[strings.ToLower(name)]
+	return a, ok
+}
+
+// ChangelogData is the value passed to custom changelog templates
+type ChangelogData struct {
+	ModVersion string
+	Date       string
+	Updates    VersionUpdateList
+}
+
+// Updated returns only the items which have a newer version
+func (v VersionUpdateList) Updated() VersionUpdateList {
+	a := make(VersionUpdateList, 0, len(v))
+	for _, i := range v {
+		if i.Latest != "" && i.Latest != i.Current {
+			a = append(a, i)
+		}
+	}
+	return a
+}
+
+// WithPrevious marks the property as updated from prev to its current value,
+// this is used for versions which are changed before generating the list
+func (v VersionUpdateList) WithPrevious(k develop.PropVersion, prev string) VersionUpdateList {
+	for n, i := range v {
+		if i.Property == k && i.Latest == "" && prev != "" && prev != i.Current {
+			v[n] = VersionUpdateItem{k, prev, i.Current}
+		}
+	}
+	return v
+}
+
+// Get returns the item for the property
+func (v VersionUpdateList) Get(k develop.PropVersion) (VersionUpdateItem, bool) {
+	for _, i := range v {
+		if i.Property == k {
+			return i, true
+		}
+	}
+	return VersionUpdateItem{}, false
+}
+
+// Summary generates a single line like "Updated to Minecraft 1.20.4, Fabric API 0.91.2"
+func (v VersionUpdateList) Summary() string {
+	u := v.Updated()
+	if len(u) == 0 {
+		return "No version updates"
+	}
+	a := make([]string, len(u))
+	for n, i := range u {
+		a[n] = i.Property.String() + " " + i.Latest
+	}
+	return "Updated to " + strings.Join(a, ", ")
+}
+
+func (v VersionUpdateList) Changelog(format ChangelogFormat, tmpl string, date time.Time) (string, error) {
+	data := ChangelogData{Date: date.Format(time.DateOnly), Updates: v.Updated()}
+	if i, ok := v.Get(develop.ModVersion); ok {
+		data.ModVersion = i.Current
+	}
+
+	var b strings.Builder
+	switch format {
+	case ChangelogKeepAChangelog:
+		if data.ModVersion == "" {
+			b.WriteString("## [Unreleased]\n")
+		} else {
+			b.WriteString("## [" + data.ModVersion + "] - " + data.Date + "\n")
+		}
+		b.WriteString("### Changed\n")
+		for _, i := range data.Updates {
+			if i.Current == "" {
+				b.WriteString("- Updated " + i.Property.String() + " to " + i.Latest + "\n")
+				continue
+			}
+			b.WriteString("- Updated " + i.Property.String() + " from " + i.Current + " to " + i.Latest + "\n")
+		}
+	case ChangelogPlainText:
+		if data.ModVersion != "" {
+			b.WriteString(data.ModVersion + " (" + data.Date + ")\n")
+		}
+		b.WriteString(v.Summary() + "\n")
+	case ChangelogTemplate:
+		t, err := template.New("changelog").Parse(tmpl)
+		if err != nil {
+			return "", fmt.Errorf("parse changelog template: %w", err)
+		}
+		err = t.Execute(&b, data)
+		if err != nil {
+			return "", fmt.Errorf("execute changelog template: %w", err)
+		}
+	default:
+		return "", fmt.Errorf("unknown changelog format")
+	}
+	return b.String(), nil
+}
+
+// PrependChangelog writes the changelog with the entry added. Keep a Changelog
+// entries are placed after the preamble and any "Unreleased" section, or merged
+// into an existing section for the same version. Other formats are prepended.
+func PrependChangelog(out io.StringWriter, in io.Reader, entry string, format ChangelogFormat) (err error) {
+	var lines []string
+	if in != nil {
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		if err = scanner.Err(); err != nil {
+			return err
+		}
+	}
+
+	entry = strings.TrimRight(entry, "\n")
+	entryLines := strings.Split(entry, "\n")
+
+	at := 0
+	if format == ChangelogKeepAChangelog {
+		at = len(lines)
+		heading := entryLines[0]
+		for n, i := range lines {
+			if !strings.HasPrefix(i, "## ") {
+				continue
+			}
+			if sameChangelogHeading(i, heading) {
+				// merge the items into the existing section
+				lines = mergeChangelogSection(lines, n, entryLines[1:])
+				return writeLines(out, lines)
+			}
+			if strings.Contains(strings.ToLower(i), "[unreleased]") {
+				continue
+			}
+			at = n
+			break
+		}
+		// keep a blank line between the preamble and the new entry
+		if at > 0 && strings.TrimSpace(lines[at-1]) != "" {
+			entryLines = append([]string{""}, entryLines...)
+		}
+	}
+	if at < len(lines) {
+		entryLines = append(entryLines, "")
+	}
+	lines = insertLines(lines, at, entryLines)
+	return writeLines(out, lines)
+}
+
+// sameChangelogHeading compares the "## [version]" part of two headings
+func sameChangelogHeading(a, b string) bool {
+	a, _, _ = strings.Cut(a, " - ")
+	b, _, _ = strings.Cut(b, " - ")
+	return strings.TrimSpace(a) == strings.TrimSpace(b)
+}
+
+// mergeChangelogSection adds the items below a matching "###" subheading in the
+// section starting at the heading index, or directly below the heading
+func mergeChangelogSection(lines []string, heading int, add []string) []string {
+	if len(add) > 0 && strings.HasPrefix(add[0], "### ") {
+		for n := heading + 1; n < len(lines) && !strings.HasPrefix(lines[n], "## "); n++ {
+			if strings.TrimSpace(lines[n]) == add[0] {
+				return insertLines(lines, n+1, add[1:])
+			}
+		}
+	}
+	return insertLines(lines, heading+1, add)
+}
+
+func insertLines(lines []string, at int, add []string) []string {
+	a := make([]string, 0, len(lines)+len(add))
+	a = append(a, lines[:at]...)
+	a = append(a, add...)
+	return append(a, lines[at:]...)
+}
+
+func writeLines(out io.StringWriter, lines []string) error {
+	for _, i := range lines {
+		if _, err := out.WriteString(i + "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
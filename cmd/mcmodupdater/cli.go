@@ -5,13 +5,23 @@ import (
 	"fmt"
 	"github.com/mrmelon54/mcmodupdater"
 	"github.com/mrmelon54/mcmodupdater/config"
-	"github.com/mrmelon54/mcmodupdater/develop"
-	"github.com/mrmelon54/mcmodupdater/develop/dev"
-	"io/fs"
 	"os"
 	"path/filepath"
 )
 
+// options contains the flags shared by all modes
+type options struct {
+	dryFlag           bool
+	mcVersion         string
+	propsPath         string
+	changelogPath     string
+	changelogFormat   string
+	changelogTemplate string
+	gitFlag           bool
+	gitBranch         string
+	unstable          bool
+}
+
 func main() {
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -19,17 +29,32 @@ func main() {
 		return
 	}
 
-	var dryFlag bool
+	var opts options
 	var noCache bool
-	var mcVersion string
 	var wdPath string
-	var propsPath string
 
-	flag.BoolVar(&dryFlag, "d", false, "Dry-run outputs the generated properties file instead of editing the file")
+	flag.BoolVar(&opts.dryFlag, "d", false, "Dry-run outputs the generated properties file instead of editing the file")
 	flag.BoolVar(&noCache, "nocache", false, "Use flag to disable cache")
-	flag.StringVar(&mcVersion, "mc", "", "Select the Minecraft version to update to, defaults to the current version")
+	flag.StringVar(&opts.mcVersion, "mc", "", "Select the Minecraft version to update to, defaults to the current version")
 	flag.StringVar(&wdPath, "p", cwd, "Change project path (defaults to current directory)")
-	flag.StringVar(&propsPath, "f", "gradle.properties", "Use custom project properties (defaults to gradle.properties)")
+	flag.StringVar(&opts.propsPath, "f", "gradle.properties", "Use custom project properties (defaults to gradle.properties)")
+	flag.StringVar(&opts.changelogPath, "changelog", "", "Prepend a changelog entry for the applied updates to this file (relative to the project path)")
+	flag.StringVar(&opts.changelogFormat, "changelog-format", "keepachangelog", "Changelog entry format: keepachangelog, plain or template")
+	flag.StringVar(&opts.changelogTemplate, "changelog-template", "", "Go template file used when the changelog format is 'template'")
+	flag.BoolVar(&opts.gitFlag, "git", false, "Create a branch and commit the updated files using the local git CLI")
+	flag.StringVar(&opts.gitBranch, "git-branch", mcmodupdater.DefaultBranchTemplate, "Go template for the branch name created in git mode")
+	flag.BoolVar(&opts.unstable, "unstable", false, "Include snapshots and other unstable Minecraft versions in reports")
+	flag.Usage = func() {
+		errPrintln("Usage:")
+		errPrintln("  mcmodupdater [flags]              update the project at -p")
+		errPrintln("  mcmodupdater [flags] scan <root>  update every project found below root")
+		errPrintln("  mcmodupdater [flags] matrix [from] [to]")
+		errPrintln("                                    show the latest dependencies for each Minecraft version")
+		errPrintln("  mcmodupdater [flags] target       find the newest Minecraft version the project can move to")
+		errPrintln()
+		errPrintln("Flags:")
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 
 	conf, err := config.Load()
@@ -54,103 +79,50 @@ func main() {
 		os.Exit(1)
 	}
 
-	tree := os.DirFS(wdPath).(fs.StatFS)
-	info, err := mcm.LoadTree(tree, propsPath)
+	switch flag.Arg(0) {
+	case "":
+		err = updateProject(mcm, opts, wdPath)
+	case "scan":
+		root := flag.Arg(1)
+		if root == "" {
+			root = wdPath
+		}
+		err = scanProjects(mcm, opts, root)
+	case "target":
+		err = findTarget(mcm, opts, wdPath)
+	case "matrix":
+		err = showMatrix(mcm, opts, flag.Arg(1), flag.Arg(2))
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
 	if err != nil {
-		errPrintln("Error:", err)
+		errPrintln("[-]", err)
 		os.Exit(1)
 	}
+}
 
-	a := make([]develop.Develop, 0)
-	if len(mcm.PlatArch().SubPlatforms) > 0 {
-		a = append(a, mcm.PlatArch())
-		for _, i := range dev.Platforms {
-			if c, ok := mcm.PlatArch().SubPlatforms[i]; ok {
-				a = append(a, c)
-			}
-		}
-	}
-
-	errPrintln("[+] Fetching version data...")
-
-	// if the platform is architectury then fetch the sub-platform caches
-	if arc, ok := info.Platform.(*dev.Architectury); ok {
-		// fetch architectury specific caches first
-		err := fetchCalls(arc)
-		if err != nil {
-			errPrintln("Error:", err)
-			os.Exit(1)
-		}
+// writeUpdate writes to a temporary update file and then moves it to the
+// target, this prevents accidentally destroying the original file
+func writeUpdate(wdPath, name string, cb func(out *os.File) error) error {
+	tmpPath := filepath.Join(wdPath, ".update.mcmodupdater")
+	fullPath := filepath.Join(wdPath, name)
 
-		// fetch sub-platform caches
-		for _, i := range dev.Platforms {
-			if c, ok := arc.SubPlatforms[i]; ok {
-				err := fetchCalls(c)
-				if err != nil {
-					errPrintln("Error:", err)
-					os.Exit(1)
-				}
-			}
-		}
-	} else {
-		// fetch platform caches
-		err := fetchCalls(info.Platform)
-		if err != nil {
-			errPrintln("Error:", err)
-			os.Exit(1)
-		}
+	uMcm, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to open '%s': %w", tmpPath, err)
 	}
-
-	//oldMc := info.Versions[develop.MinecraftVersion]
-	info.Versions[develop.MinecraftVersion] = mcVersion
-	ver := mcm.VersionUpdateList(info)
-
-	if dryFlag {
-		// output the updated properties file to stdout
-		err := mcm.UpdateToVersion(os.Stdout, tree, propsPath, ver.ChangeToLatest())
-		if err != nil {
-			errPrintln("[-] Failed to update version numbers:", err)
-			os.Exit(1)
-		}
-	} else {
-		// create temporary update file
-		// this prevents accidentally destroying the original gradle properties
-		tmpPath := filepath.Join(wdPath, ".update.mcmodupdater")
-		propsFullPath := filepath.Join(wdPath, propsPath)
-
-		uMcm, err := os.Create(tmpPath)
-		if err != nil {
-			errPrintf("[-] Failed to open '%s'", tmpPath)
-			os.Exit(1)
-		}
-		//goland:noinspection GoUnhandledErrorResult
-		defer uMcm.Close()
-
-		// output the updated properties file
-		err = mcm.UpdateToVersion(uMcm, tree, propsPath, ver.ChangeToLatest())
-		if err != nil {
-			errPrintln("[-] Failed to update version numbers:", err)
-			os.Exit(1)
-		}
-
-		// if everything succeeded then move the temporary update file
-		// to 'gradle.properties'
-		err = os.Rename(tmpPath, propsFullPath)
-		if err != nil {
-			errPrintf("[-] Failed to move '%s' => '%s'", tmpPath, propsFullPath)
-			os.Exit(1)
-		}
-
-		errPrintln("[+] Automatic update succeeded")
+	err = cb(uMcm)
+	_ = uMcm.Close()
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return err
 	}
-}
 
-func fetchCalls(platform develop.Develop) error {
-	for _, i := range platform.FetchCalls() {
-		err := i.Call()
-		if err != nil {
-			return err
-		}
+	// if everything succeeded then move the temporary update file
+	err = os.Rename(tmpPath, fullPath)
+	if err != nil {
+		return fmt.Errorf("failed to move '%s' => '%s': %w", tmpPath, fullPath, err)
 	}
 	return nil
 }
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"github.com/mrmelon54/mcmodupdater"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// defaultMatrixSize is the number of versions shown when no range is selected
+const defaultMatrixSize = 10
+
+// showMatrix prints the latest version of each dependency for the Minecraft
+// versions between from and to, missing releases are highlighted
+func showMatrix(mcm *mcmodupdater.McModUpdater, opts options, from, to string) error {
+	errPrintln("[+] Fetching version data...")
+	err := mcm.FetchMatrix(mcmodupdater.MatrixProps)
+	if err != nil {
+		return err
+	}
+	game, err := mcm.GameVersions()
+	if err != nil {
+		return err
+	}
+	mcVersions, err := mcmodupdater.GameVersionRange(game, from, to, opts.unstable)
+	if err != nil {
+		return err
+	}
+	if from == "" && to == "" && len(mcVersions) > defaultMatrixSize {
+		mcVersions = mcVersions[:defaultMatrixSize]
+	}
+
+	rows := mcm.CompatibilityMatrix(mcVersions, mcmodupdater.MatrixProps)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	header := []string{"Minecraft"}
+	for _, i := range mcmodupdater.MatrixProps {
+		header = append(header, i.String())
+	}
+	_, _ = fmt.Fprintln(w, strings.Join(header, "\t")+"\t")
+	for _, r := range rows {
+		line := []string{r.Minecraft}
+		for _, i := range mcmodupdater.MatrixProps {
+			if v, ok := r.Versions[i]; ok {
+				line = append(line, v)
+			} else {
+				line = append(line, "✗ missing")
+			}
+		}
+		_, _ = fmt.Fprintln(w, strings.Join(line, "\t")+"\t")
+	}
+	err = w.Flush()
+	if err != nil {
+		return err
+	}
+
+	for _, r := range rows {
+		if missing := r.Missing(mcmodupdater.MatrixProps); len(missing) > 0 {
+			names := make([]string, len(missing))
+			for n, i := range missing {
+				names[n] = i.String()
+			}
+			errPrintf("[-] %s is missing: %s\n", r.Minecraft, strings.Join(names, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"github.com/mrmelon54/mcmodupdater"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// scanProjects finds every project below root and prints an update summary
+// for each of them, the updates are applied unless this is a dry-run
+func scanProjects(mcm *mcmodupdater.McModUpdater, opts options, root string) error {
+	if opts.gitFlag {
+		return fmt.Errorf("git mode is not supported when scanning multiple projects")
+	}
+
+	projects, err := mcm.ScanProjects(os.DirFS(root))
+	if err != nil {
+		return err
+	}
+	if len(projects) == 0 {
+		return fmt.Errorf("no projects found in '%s'", root)
+	}
+	errPrintf("[+] Found %d projects\n", len(projects))
+	errPrintln("[+] Fetching version data...")
+
+	failed := 0
+	for _, p := range projects {
+		wdPath := filepath.Join(root, p)
+		tree := os.DirFS(wdPath).(fs.StatFS)
+		project, err := loadProject(mcm, opts, tree)
+		if err != nil {
+			errPrintf("[-] %s: %s\n", p, err)
+			failed++
+			continue
+		}
+
+		fmt.Printf("%s (%s)\n", p, project.platform)
+		updated := project.all().Updated()
+		if len(updated) == 0 {
+			fmt.Println("  up to date")
+		}
+		for _, i := range project.props {
+			for _, j := range i.ver.Updated() {
+				if len(project.props) > 1 {
+					fmt.Printf("  %s: %s: %s -> %s\n", i.path, j.Property, j.Current, j.Latest)
+					continue
+				}
+				fmt.Printf("  %s: %s -> %s\n", j.Property, j.Current, j.Latest)
+			}
+		}
+
+		if opts.dryFlag || len(updated) == 0 {
+			continue
+		}
+		_, err = applyUpdates(mcm, opts, wdPath, tree, project)
+		if err != nil {
+			errPrintf("[-] %s: %s\n", p, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d projects failed", failed, len(projects))
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"github.com/mrmelon54/mcmodupdater"
+	"github.com/mrmelon54/mcmodupdater/develop"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// findTarget prints the newest Minecraft version which every dependency of the
+// project supports, along with the dependencies blocking newer versions
+func findTarget(mcm *mcmodupdater.McModUpdater, opts options, wdPath string) error {
+	tree := os.DirFS(wdPath).(fs.StatFS)
+	info, err := mcm.LoadTree(tree, opts.propsPath)
+	if err != nil {
+		return err
+	}
+
+	errPrintln("[+] Fetching version data...")
+	err = mcm.Fetch(info.Platform)
+	if err != nil {
+		return err
+	}
+	game, err := mcm.GameVersions()
+	if err != nil {
+		return err
+	}
+
+	// only check versions newer than the current version
+	current := info.Versions[develop.MinecraftVersion]
+	candidates, err := mcmodupdater.GameVersionRange(game, current, "", opts.unstable)
+	if err != nil {
+		candidates, err = mcmodupdater.GameVersionRange(game, "", "", opts.unstable)
+		if err != nil {
+			return err
+		}
+	}
+
+	target, rejected := mcm.FindTargetVersion(info, candidates)
+	for _, i := range rejected {
+		names := make([]string, len(i.Blocking))
+		for n, j := range i.Blocking {
+			names[n] = j.String()
+		}
+		fmt.Printf("%s: blocked by %s\n", i.Minecraft, strings.Join(names, ", "))
+	}
+	if target == "" {
+		return fmt.Errorf("no Minecraft version is supported by every dependency")
+	}
+	if target == current {
+		fmt.Printf("%s: already on the newest supported version\n", target)
+		return nil
+	}
+	fmt.Printf("%s: supported by every dependency\n", target)
+	return nil
+}
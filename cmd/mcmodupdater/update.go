@@ -0,0 +1,340 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/mrmelon54/mcmodupdater"
+	"github.com/mrmelon54/mcmodupdater/develop"
+	"github.com/mrmelon54/mcmodupdater/git"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// projectUpdate contains the resolved updates for each properties file of a
+// project, multi-version projects have one properties file per version folder
+type projectUpdate struct {
+	platform string
+	props    []propsUpdate
+	files    []mcmodupdater.FileUpdate
+}
+
+type propsUpdate struct {
+	path string
+	ver  mcmodupdater.VersionUpdateList
+}
+
+// all joins the update lists of every properties file, shared properties of
+// multi-version projects are only listed once
+func (p *projectUpdate) all() mcmodupdater.VersionUpdateList {
+	a := make(mcmodupdater.VersionUpdateList, 0)
+	seen := make(map[mcmodupdater.VersionUpdateItem]bool)
+	for _, i := range p.props {
+		for _, j := range i.ver {
+			if !seen[j] {
+				seen[j] = true
+				a = append(a, j)
+			}
+		}
+	}
+	return a
+}
+
+// loadProject reads the project and resolves the updates for the target
+// Minecraft version, defaulting to the current version of the project
+func loadProject(mcm *mcmodupdater.McModUpdater, opts options, tree fs.StatFS) (*projectUpdate, error) {
+	if len(mcm.MultiVersionDirs(tree, opts.propsPath)) > 0 {
+		return loadMultiVersionProject(mcm, opts, tree)
+	}
+
+	info, err := mcm.LoadTree(tree, opts.propsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	err = mcm.Fetch(info.Platform)
+	if err != nil {
+		return nil, err
+	}
+
+	oldMc := info.Versions[develop.MinecraftVersion]
+	if opts.mcVersion != "" {
+		info.Versions[develop.MinecraftVersion] = opts.mcVersion
+	}
+	ver := mcm.VersionUpdateList(info).WithPrevious(develop.MinecraftVersion, oldMc)
+	return &projectUpdate{
+		platform: platformName(info),
+		props:    []propsUpdate{{opts.propsPath, ver}},
+		files:    mcm.FileUpdates(tree, ver),
+	}, nil
+}
+
+// platformName describes the platform along with any sub-platforms
+func platformName(info *develop.PlatformVersions) string {
+	if len(info.SubPlatforms) == 0 {
+		return info.Platform.Platform().Name
+	}
+	a := make([]string, len(info.SubPlatforms))
+	for n, i := range info.SubPlatforms {
+		a[n] = i.Name
+	}
+	return info.Platform.Platform().Name + " (" + strings.Join(a, ", ") + ")"
+}
+
+// loadMultiVersionProject resolves the updates for each version folder using
+// its own Minecraft version, the root properties file is updated once
+func loadMultiVersionProject(mcm *mcmodupdater.McModUpdater, opts options, tree fs.StatFS) (*projectUpdate, error) {
+	if opts.mcVersion != "" {
+		return nil, fmt.Errorf("cannot select a Minecraft version for a multi-version project")
+	}
+	info, err := mcm.LoadMultiVersionTree(tree, opts.propsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	err = mcm.Fetch(info.Root.Platform)
+	if err != nil {
+		return nil, err
+	}
+
+	root, versions := mcm.MultiVersionUpdateLists(info)
+	files, err := mcm.MultiVersionFileUpdates(tree, info, root)
+	if err != nil {
+		return nil, err
+	}
+	p := &projectUpdate{
+		platform: platformName(info.Root) + " multi-version",
+		props:    make([]propsUpdate, 0, len(versions)+1),
+		files:    files,
+	}
+	if len(root) > 0 {
+		p.props = append(p.props, propsUpdate{opts.propsPath, root})
+	}
+	for n, i := range info.Versions {
+		p.props = append(p.props, propsUpdate{path.Join(i.Dir, opts.propsPath), versions[n]})
+	}
+	return p, nil
+}
+
+func updateProject(mcm *mcmodupdater.McModUpdater, opts options, wdPath string) error {
+	var repo *git.Repo
+	if opts.gitFlag {
+		var err error
+		repo, err = git.Open(wdPath)
+		if err != nil {
+			return err
+		}
+		dirty, err := repo.IsDirty()
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("refusing to run on a dirty git tree, commit or stash your changes first")
+		}
+	}
+
+	tree := os.DirFS(wdPath).(fs.StatFS)
+
+	errPrintln("[+] Fetching version data...")
+	project, err := loadProject(mcm, opts, tree)
+	if err != nil {
+		return err
+	}
+	ver := project.all()
+
+	var branchName, commitMessage string
+	if repo != nil {
+		if len(ver.Updated()) == 0 {
+			errPrintln("[+] No updates found, skipping git branch and commit")
+			repo = nil
+		} else {
+			branchName, err = ver.BranchName(opts.gitBranch)
+			if err != nil {
+				return fmt.Errorf("failed to generate branch name: %w", err)
+			}
+			commitMessage = ver.CommitMessage()
+			if !opts.dryFlag {
+				err = repo.CheckBranch(branchName)
+				if err != nil {
+					return fmt.Errorf("failed to create git branch: %w", err)
+				}
+			}
+		}
+	}
+
+	if opts.dryFlag {
+		// output the updated properties files to stdout
+		for _, i := range project.props {
+			if !propsExists(tree, i.path) {
+				continue
+			}
+			if len(project.props) > 1 {
+				errPrintf("[+] Updated '%s':\n", i.path)
+			}
+			err := mcm.UpdateToVersion(os.Stdout, tree, i.path, i.ver.ChangeToLatest())
+			if err != nil {
+				return fmt.Errorf("failed to update version numbers: %w", err)
+			}
+		}
+		for _, i := range project.files {
+			updated, changed, err := renderFileUpdate(tree, i)
+			if err != nil {
+				return fmt.Errorf("failed to update '%s': %w", i.Name, err)
+			}
+			if changed {
+				errPrintf("[+] Updated '%s':\n", i.Name)
+				fmt.Print(updated)
+			}
+		}
+		if opts.changelogPath != "" {
+			changelogEntry, _, err := generateChangelog(opts, ver)
+			if err != nil {
+				return err
+			}
+			errPrintf("[+] Changelog entry for '%s':\n", opts.changelogPath)
+			errPrintln(changelogEntry)
+		}
+		if repo != nil {
+			errPrintf("[+] Git branch '%s' with commit message:\n", branchName)
+			errPrintln(commitMessage)
+		}
+		return nil
+	}
+
+	changed, err := applyUpdates(mcm, opts, wdPath, tree, project)
+	if err != nil {
+		return err
+	}
+
+	// the branch is only created once every file has been written
+	if repo != nil {
+		err = repo.CreateBranch(branchName)
+		if err != nil {
+			return fmt.Errorf("failed to create git branch: %w", err)
+		}
+		errPrintf("[+] Created git branch '%s'\n", branchName)
+
+		err = repo.Commit(commitMessage, changed...)
+		if err != nil {
+			return fmt.Errorf("failed to commit updated files: %w", err)
+		}
+		errPrintln("[+] Committed updated files")
+	}
+
+	errPrintln("[+] Automatic update succeeded")
+	return nil
+}
+
+// applyUpdates writes the updated files to the project and returns the paths
+// of the files which were written
+func applyUpdates(mcm *mcmodupdater.McModUpdater, opts options, wdPath string, tree fs.StatFS, project *projectUpdate) ([]string, error) {
+	changed := make([]string, 0, len(project.props)+1)
+
+	// generate the changelog first so an invalid format or template leaves the
+	// project untouched
+	var changelogEntry string
+	var clFormat mcmodupdater.ChangelogFormat
+	if opts.changelogPath != "" {
+		var err error
+		changelogEntry, clFormat, err = generateChangelog(opts, project.all())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// output the updated properties files
+	for _, i := range project.props {
+		if !propsExists(tree, i.path) {
+			continue
+		}
+		err := writeUpdate(wdPath, i.path, func(out *os.File) error {
+			return mcm.UpdateToVersion(out, tree, i.path, i.ver.ChangeToLatest())
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to update version numbers in '%s': %w", i.path, err)
+		}
+		changed = append(changed, i.path)
+	}
+	// output the other updated files, unchanged files are left alone
+	for _, i := range project.files {
+		updated, ok, err := renderFileUpdate(tree, i)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update '%s': %w", i.Name, err)
+		}
+		if !ok {
+			continue
+		}
+		err = writeUpdate(wdPath, i.Name, func(out *os.File) error {
+			_, err := out.WriteString(updated)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to update '%s': %w", i.Name, err)
+		}
+		changed = append(changed, i.Name)
+	}
+	if opts.changelogPath != "" {
+		err := writeUpdate(wdPath, opts.changelogPath, func(out *os.File) error {
+			cl, err := tree.Open(opts.changelogPath)
+			if errors.Is(err, fs.ErrNotExist) {
+				return mcmodupdater.PrependChangelog(out, nil, changelogEntry, clFormat)
+			}
+			if err != nil {
+				return err
+			}
+			//goland:noinspection GoUnhandledErrorResult
+			defer cl.Close()
+			return mcmodupdater.PrependChangelog(out, cl, changelogEntry, clFormat)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to update changelog: %w", err)
+		}
+		changed = append(changed, opts.changelogPath)
+	}
+	return changed, nil
+}
+
+// propsExists checks for the properties file, plugin projects may only keep
+// their versions in the build scripts
+func propsExists(tree fs.FS, name string) bool {
+	_, err := fs.Stat(tree, name)
+	return err == nil
+}
+
+// renderFileUpdate returns the updated file contents and whether they differ
+// from the original file
+func renderFileUpdate(tree fs.FS, f mcmodupdater.FileUpdate) (string, bool, error) {
+	original, err := fs.ReadFile(tree, f.Name)
+	if err != nil {
+		return "", false, err
+	}
+	var b strings.Builder
+	err = f.Update(&b, bytes.NewReader(original))
+	if err != nil {
+		return "", false, err
+	}
+	return b.String(), b.String() != string(original), nil
+}
+
+func generateChangelog(opts options, ver mcmodupdater.VersionUpdateList) (string, mcmodupdater.ChangelogFormat, error) {
+	clFormat, ok := mcmodupdater.ChangelogFormatFromName(opts.changelogFormat)
+	if !ok {
+		return "", 0, fmt.Errorf("unknown changelog format: %s", opts.changelogFormat)
+	}
+	var tmpl []byte
+	if clFormat == mcmodupdater.ChangelogTemplate {
+		var err error
+		tmpl, err = os.ReadFile(opts.changelogTemplate)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to read changelog template: %w", err)
+		}
+	}
+	changelogEntry, err := ver.Changelog(clFormat, string(tmpl), time.Now())
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to generate changelog: %w", err)
+	}
+	return changelogEntry, clFormat, nil
+}
@@ -0,0 +1,58 @@
+package mcmodupdater
+
+import (
+	"fmt"
+	"github.com/mrmelon54/mcmodupdater/develop"
+	"strings"
+	"text/template"
+)
+
+const DefaultBranchTemplate = "update/mc-{{.Minecraft}}"
+
+// BranchData is the value passed to branch name templates
+type BranchData struct {
+	Minecraft  string
+	ModVersion string
+	Updates    VersionUpdateList
+}
+
+func (v VersionUpdateList) BranchName(tmpl string) (string, error) {
+	if tmpl == "" {
+		tmpl = DefaultBranchTemplate
+	}
+	t, err := template.New("branch").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("parse branch template: %w", err)
+	}
+	data := BranchData{Updates: v.Updated()}
+	if i, ok := v.Get(develop.MinecraftVersion); ok {
+		data.Minecraft = i.Current
+		if i.Latest != "" {
+			data.Minecraft = i.Latest
+		}
+	}
+	if i, ok := v.Get(develop.ModVersion); ok {
+		data.ModVersion = i.Current
+	}
+	var b strings.Builder
+	err = t.Execute(&b, data)
+	if err != nil {
+		return "", fmt.Errorf("execute branch template: %w", err)
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+// CommitMessage generates a summary line followed by a list of the version bumps
+func (v VersionUpdateList) CommitMessage() string {
+	var b strings.Builder
+	b.WriteString(v.Summary())
+	b.WriteString("\n\n")
+	for _, i := range v.Updated() {
+		if i.Current == "" {
+			b.WriteString("- " + i.Property.String() + ": " + i.Latest + "\n")
+			continue
+		}
+		b.WriteString("- " + i.Property.String() + ": " + i.Current + " -> " + i.Latest + "\n")
+	}
+	return b.String()
+}
@@ -11,21 +11,49 @@ type DevelopConfig struct {
 	Forge        ForgeDevelopConfig        `yaml:"forge"`
 	Quilt        QuiltDevelopConfig        `yaml:"quilt"`
 	NeoForge     NeoForgeDevelopConfig     `yaml:"neoforge"`
+	LiteLoader   LiteLoaderDevelopConfig   `yaml:"liteloader"`
+	LegacyFabric LegacyFabricDevelopConfig `yaml:"legacyFabric"`
+	Babric       BabricDevelopConfig       `yaml:"babric"`
+	Ornithe      OrnitheDevelopConfig      `yaml:"ornithe"`
+	Paper        PaperDevelopConfig        `yaml:"paper"`
+	Velocity     VelocityDevelopConfig     `yaml:"velocity"`
+	BungeeCord   BungeeCordDevelopConfig   `yaml:"bungeecord"`
+	Sponge       SpongeDevelopConfig       `yaml:"sponge"`
+	Gradle       GradleDevelopConfig       `yaml:"gradle"`
+	Mojang       MojangDevelopConfig       `yaml:"mojang"`
 }
 
 type ArchitecturyDevelopConfig struct {
-	Api string `yaml:"api"`
+	Api         string `yaml:"api"`
+	Loom        string `yaml:"loom"`
+	LoomChannel string `yaml:"loomChannel"`
 }
 
 type FabricDevelopConfig struct {
-	Game   string `yaml:"game"`
-	Yarn   string `yaml:"yarn"`
-	Loader string `yaml:"loader"`
-	Api    string `yaml:"api"`
+	Game        string `yaml:"game"`
+	Yarn        string `yaml:"yarn"`
+	Loader      string `yaml:"loader"`
+	Api         string `yaml:"api"`
+	Loom        string `yaml:"loom"`
+	LoomChannel string `yaml:"loomChannel"`
+	// DependencyStrategy is used for fabric.mod.json constraints: "keep",
+	// "exact", "tilde" or "gte"
+	DependencyStrategy string `yaml:"dependencyStrategy"`
 }
 
 type ForgeDevelopConfig struct {
-	Api string `yaml:"api"`
+	Api                string `yaml:"api"`
+	ForgeGradle        string `yaml:"forgeGradle"`
+	ForgeGradleChannel string `yaml:"forgeGradleChannel"`
+	// McpStable and McpSnapshot are the MCP mappings used by legacy projects
+	McpStable   string `yaml:"mcpStable"`
+	McpSnapshot string `yaml:"mcpSnapshot"`
+	// McpChannel is "stable" or "snapshot", stable falls back to snapshot
+	McpChannel string `yaml:"mcpChannel"`
+	Promotions string `yaml:"promotions"`
+	// Promotion is "recommended" or "latest", projects can override this with
+	// the forge_promotion property
+	Promotion string `yaml:"promotion"`
 }
 
 type QuiltDevelopConfig struct {
@@ -35,8 +63,77 @@ type QuiltDevelopConfig struct {
 	Loader               string `yaml:"loader"`
 	QuiltStandardLibrary string `yaml:"quiltStandardLibrary"`
 	QuiltedFabricApi     string `yaml:"quiltedFabricApi"`
+	// DependencyStrategy is used for quilt.mod.json constraints: "keep",
+	// "exact", "tilde" or "gte"
+	DependencyStrategy string `yaml:"dependencyStrategy"`
+}
+
+type LegacyFabricDevelopConfig struct {
+	Game   string `yaml:"game"`
+	Yarn   string `yaml:"yarn"`
+	Loader string `yaml:"loader"`
+	Api    string `yaml:"api"`
+}
+
+type BabricDevelopConfig struct {
+	Loader string `yaml:"loader"`
+	// Mappings is the maven metadata for Biny, the Babric fork of Yarn
+	Mappings string `yaml:"mappings"`
+}
+
+type OrnitheDevelopConfig struct {
+	Game    string `yaml:"game"`
+	Feather string `yaml:"feather"`
+	Loader  string `yaml:"loader"`
+	Osl     string `yaml:"osl"`
+}
+
+type PaperDevelopConfig struct {
+	Api string `yaml:"api"`
+}
+
+type VelocityDevelopConfig struct {
+	Api        string `yaml:"api"`
+	ApiChannel string `yaml:"apiChannel"`
+}
+
+type BungeeCordDevelopConfig struct {
+	Api          string `yaml:"api"`
+	WaterfallApi string `yaml:"waterfallApi"`
+	ApiChannel   string `yaml:"apiChannel"`
+}
+
+type SpongeDevelopConfig struct {
+	Api string `yaml:"api"`
+	// ApiChannel is "stable" or "snapshot", stable falls back to snapshots for
+	// API versions without a release
+	ApiChannel string `yaml:"apiChannel"`
+}
+
+type LiteLoaderDevelopConfig struct {
+	Versions string `yaml:"versions"`
+	// Channel is "stable" or "snapshot", stable falls back to snapshot builds
+	// for Minecraft versions without a release
+	Channel string `yaml:"channel"`
 }
 
 type NeoForgeDevelopConfig struct {
 	Api string `yaml:"api"`
+	// LegacyApi is the net.neoforged:forge artifact used for Minecraft 1.20.1
+	LegacyApi           string `yaml:"legacyApi"`
+	NeoGradle           string `yaml:"neoGradle"`
+	NeoGradleChannel    string `yaml:"neoGradleChannel"`
+	ModDevGradle        string `yaml:"modDevGradle"`
+	ModDevGradleChannel string `yaml:"modDevGradleChannel"`
+}
+
+type GradleDevelopConfig struct {
+	Versions string `yaml:"versions"`
+	// Strategy is "latest" for the newest release or "minimum" for the
+	// minimum version required by the build plugins
+	Strategy string `yaml:"strategy"`
+}
+
+type MojangDevelopConfig struct {
+	Manifest string `yaml:"manifest"`
 }
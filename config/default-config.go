@@ -11,16 +11,28 @@ func DefaultConfig() Config {
 	return Config{
 		Develop: DevelopConfig{
 			Architectury: ArchitecturyDevelopConfig{
-				Api: "https://api.modrinth.com/v2/project/architectury-api/version",
+				Api:         "https://api.modrinth.com/v2/project/architectury-api/version",
+				Loom:        "https://maven.architectury.dev/dev/architectury/loom/dev.architectury.loom.gradle.plugin/maven-metadata.xml",
+				LoomChannel: "snapshot",
 			},
 			Fabric: FabricDevelopConfig{
-				Game:   "https://meta.fabricmc.net/v2/versions/game",
-				Yarn:   "https://meta.fabricmc.net/v2/versions/yarn",
-				Loader: "https://meta.fabricmc.net/v2/versions/loader",
-				Api:    "https://maven.fabricmc.net/net/fabricmc/fabric-api/fabric-api/maven-metadata.xml",
+				Game:               "https://meta.fabricmc.net/v2/versions/game",
+				Yarn:               "https://meta.fabricmc.net/v2/versions/yarn",
+				Loader:             "https://meta.fabricmc.net/v2/versions/loader",
+				Api:                "https://maven.fabricmc.net/net/fabricmc/fabric-api/fabric-api/maven-metadata.xml",
+				Loom:               "https://maven.fabricmc.net/fabric-loom/fabric-loom.gradle.plugin/maven-metadata.xml",
+				LoomChannel:        "snapshot",
+				DependencyStrategy: "keep",
 			},
 			Forge: ForgeDevelopConfig{
-				Api: "https://maven.minecraftforge.net/net/minecraftforge/forge/maven-metadata.xml",
+				Api:                "https://maven.minecraftforge.net/net/minecraftforge/forge/maven-metadata.xml",
+				ForgeGradle:        "https://maven.minecraftforge.net/net/minecraftforge/gradle/net.minecraftforge.gradle.gradle.plugin/maven-metadata.xml",
+				ForgeGradleChannel: "stable",
+				McpStable:          "https://maven.minecraftforge.net/de/oceanlabs/mcp/mcp_stable/maven-metadata.xml",
+				McpSnapshot:        "https://maven.minecraftforge.net/de/oceanlabs/mcp/mcp_snapshot/maven-metadata.xml",
+				McpChannel:         "stable",
+				Promotions:         "https://files.minecraftforge.net/net/minecraftforge/forge/promotions_slim.json",
+				Promotion:          "latest",
 			},
 			Quilt: QuiltDevelopConfig{
 				Game:                 "https://meta.quiltmc.org/v3/versions/game",
@@ -29,9 +41,58 @@ func DefaultConfig() Config {
 				Loader:               "https://meta.quiltmc.org/v3/versions/loader",
 				QuiltStandardLibrary: "https://maven.quiltmc.org/repository/release/org/quiltmc/qsl/maven-metadata.xml",
 				QuiltedFabricApi:     "https://maven.quiltmc.org/repository/release/org/quiltmc/quilted-fabric-api/quilted-fabric-api/maven-metadata.xml",
+				DependencyStrategy:   "keep",
 			},
 			NeoForge: NeoForgeDevelopConfig{
-				Api: "https://maven.neoforged.net/net/neoforged/neoforge/maven-metadata.xml",
+				Api:                 "https://maven.neoforged.net/net/neoforged/neoforge/maven-metadata.xml",
+				LegacyApi:           "https://maven.neoforged.net/releases/net/neoforged/forge/maven-metadata.xml",
+				NeoGradle:           "https://maven.neoforged.net/releases/net/neoforged/gradle/userdev/net.neoforged.gradle.userdev.gradle.plugin/maven-metadata.xml",
+				NeoGradleChannel:    "stable",
+				ModDevGradle:        "https://maven.neoforged.net/releases/net/neoforged/moddev/net.neoforged.moddev.gradle.plugin/maven-metadata.xml",
+				ModDevGradleChannel: "beta",
+			},
+			LegacyFabric: LegacyFabricDevelopConfig{
+				Game:   "https://meta.legacyfabric.net/v2/versions/game",
+				Yarn:   "https://meta.legacyfabric.net/v2/versions/yarn",
+				Loader: "https://meta.legacyfabric.net/v2/versions/loader",
+				Api:    "https://maven.legacyfabric.net/net/legacyfabric/legacy-fabric-api/legacy-fabric-api/maven-metadata.xml",
+			},
+			Babric: BabricDevelopConfig{
+				Loader:   "https://meta.babric.glass-launcher.net/v2/versions/loader",
+				Mappings: "https://maven.glass-launcher.net/releases/net/glasslauncher/biny/maven-metadata.xml",
+			},
+			Ornithe: OrnitheDevelopConfig{
+				Game:    "https://meta.ornithemc.net/v3/versions/game",
+				Feather: "https://meta.ornithemc.net/v3/versions/gen2/feather",
+				Loader:  "https://meta.ornithemc.net/v3/versions/fabric-loader",
+				Osl:     "https://maven.ornithemc.net/releases/net/ornithemc/osl/maven-metadata.xml",
+			},
+			Paper: PaperDevelopConfig{
+				Api: "https://repo.papermc.io/repository/maven-public/io/papermc/paper/paper-api/maven-metadata.xml",
+			},
+			Velocity: VelocityDevelopConfig{
+				Api:        "https://repo.papermc.io/repository/maven-public/com/velocitypowered/velocity-api/maven-metadata.xml",
+				ApiChannel: "snapshot",
+			},
+			BungeeCord: BungeeCordDevelopConfig{
+				Api:          "https://central.sonatype.com/repository/maven-snapshots/net/md-5/bungeecord-api/maven-metadata.xml",
+				WaterfallApi: "https://repo.papermc.io/repository/maven-public/io/github/waterfallmc/waterfall-api/maven-metadata.xml",
+				ApiChannel:   "snapshot",
+			},
+			Sponge: SpongeDevelopConfig{
+				Api:        "https://repo.spongepowered.org/repository/maven-public/org/spongepowered/spongeapi/maven-metadata.xml",
+				ApiChannel: "stable",
+			},
+			LiteLoader: LiteLoaderDevelopConfig{
+				Versions: "https://dl.liteloader.com/versions/versions.json",
+				Channel:  "stable",
+			},
+			Gradle: GradleDevelopConfig{
+				Versions: "https://services.gradle.org/versions/all",
+				Strategy: "minimum",
+			},
+			Mojang: MojangDevelopConfig{
+				Manifest: "https://piston-meta.mojang.com/mc/game/version_manifest_v2.json",
 			},
 		},
 		Cache: true,
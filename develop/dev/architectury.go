@@ -2,15 +2,19 @@ package dev
 
 import (
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"github.com/magiconair/properties"
 	"github.com/mrmelon54/mcmodupdater/config"
 	"github.com/mrmelon54/mcmodupdater/develop"
+	"github.com/mrmelon54/mcmodupdater/edit"
+	"github.com/mrmelon54/mcmodupdater/meta"
 	"github.com/mrmelon54/mcmodupdater/meta/shared"
 	"github.com/mrmelon54/mcmodupdater/utils"
 	"io"
 	"io/fs"
 	"sort"
+	"strings"
 )
 
 var PlatformArchitectury = develop.DevPlatform{Name: "Architectury"}
@@ -25,6 +29,7 @@ type Architectury struct {
 type ArchitecturyMeta struct {
 	done chan struct{}
 	Api  shared.ModrinthVersionList
+	Loom meta.ArchitecturyLoomMeta
 }
 
 func ForArchitectury(conf config.DevelopConfig, cache string) develop.Develop {
@@ -40,34 +45,57 @@ func (f *Architectury) Platform() develop.DevPlatform {
 }
 
 func (f *Architectury) FetchCalls() []develop.DevFetch {
-	return []develop.DevFetch{{"Architectury", f.fetchArchApi}}
+	return []develop.DevFetch{{"Architectury", f.fetchArchApi}, {"Architectury Loom", f.fetchArchLoom}}
 }
 
 func (f *Architectury) ValidTree(tree fs.FS) bool {
-	if !genericCheckPathExists(tree, "settings.gradle") {
+	if _, ok := genericCheckOnePathExists(tree, edit.GradleSettingsPaths...); !ok {
 		return false
 	}
-	if !genericCheckPathExists(tree, "common/build.gradle") {
-		return false
+	_, ok := genericCheckOnePathExists(tree, "common/build.gradle", "common/build.gradle.kts")
+	return ok
+}
+
+// ForTree returns a copy of the platform with the sub-platforms used by the
+// tree, sub-projects are found from the settings includes and the
+// enabled_platforms property then validated against their own platform
+func (f *Architectury) ForTree(tree fs.FS, platforms map[develop.DevPlatform]develop.Develop) *Architectury {
+	names := make(map[string]bool)
+	for _, i := range edit.GradleSettingsPaths {
+		r, err := tree.Open(i)
+		if err != nil {
+			continue
+		}
+		includes, _ := edit.ReadGradleIncludes(r)
+		_ = r.Close()
+		for _, j := range includes {
+			names[j] = true
+		}
+	}
+	if props, err := genericReadOptionalProps(tree, ""); err == nil {
+		for _, i := range strings.Split(props["enabled_platforms"], ",") {
+			if i = strings.TrimSpace(i); i != "" {
+				names[i] = true
+			}
+		}
 	}
 
-	// probably architectury, now detect the sub-platforms
+	a := *f
+	a.SubPlatforms = make(map[develop.DevPlatform]develop.Develop)
 	for _, i := range Platforms {
-		if i == PlatformArchitectury {
+		p, ok := platforms[i]
+		if !ok || i.Sub == "" || !names[i.Sub] {
 			continue
 		}
-		sub, err := fs.Sub(tree, i.Name)
+		sub, err := fs.Sub(tree, i.Sub)
 		if err != nil {
 			continue
 		}
-		if subPlat, ok := f.SubPlatforms[i]; ok {
-			if !subPlat.ValidTree(sub) {
-				delete(f.SubPlatforms, i)
-			}
+		if p.ValidTree(sub) {
+			a.SubPlatforms[i] = p
 		}
 	}
-
-	return true
+	return &a
 }
 
 func (f *Architectury) ReadVersionFile(tree fs.FS, name string) (map[develop.PropVersion]string, error) {
@@ -78,7 +106,12 @@ func (f *Architectury) ReadVersionFile(tree fs.FS, name string) (map[develop.Pro
 	if err != nil {
 		return nil, fmt.Errorf("open gradle.properties: %w", err)
 	}
-	return f.ReadVersions(gradlePropFile)
+	a, err := f.ReadVersions(gradlePropFile)
+	if err != nil {
+		return nil, err
+	}
+	mapPluginVersions(a, tree)
+	return a, nil
 }
 
 func (f *Architectury) ReadVersions(r io.Reader) (map[develop.PropVersion]string, error) {
@@ -96,6 +129,7 @@ func (f *Architectury) ReadVersions(r io.Reader) (map[develop.PropVersion]string
 	mapProp(a, develop.ModVersion, propM)
 	mapProp(a, develop.MinecraftVersion, propM)
 	mapProp(a, develop.ArchitecturyVersion, propM)
+	mapProp(a, develop.ArchitecturyLoomVersion, propM)
 	if _, ok := f.SubPlatforms[PlatformFabric]; ok {
 		mapProp(a, develop.FabricLoaderVersion, propM)
 		mapProp(a, develop.FabricApiVersion, propM)
@@ -116,10 +150,13 @@ func (f *Architectury) ReadVersions(r io.Reader) (map[develop.PropVersion]string
 func (f *Architectury) LatestVersion(prop develop.PropVersion, mcVersion string) (string, bool) {
 	latestArchApi := f.Meta.Api.FilterGameVersions(mcVersion).GetLatest()
 	if prop == develop.ArchitecturyVersion {
-		return latestArchApi, true
+		return latestArchApi, latestArchApi != ""
 	}
-	for _, p := range f.SubPlatforms {
-		if a, ok := p.LatestVersion(prop, mcVersion); ok {
+	if prop == develop.ArchitecturyLoomVersion {
+		return shared.LatestChannelMavenVersion(shared.MavenMeta(f.Meta.Loom), f.Conf.LoomChannel)
+	}
+	for _, i := range f.SubPlatformList() {
+		if a, ok := f.SubPlatforms[i].LatestVersion(prop, mcVersion); ok {
 			return a, true
 		}
 	}
@@ -130,6 +167,17 @@ func (f *Architectury) LatestLoaderVersion(_ string) (string, error) {
 	return "", fmt.Errorf("no loader defined")
 }
 
+// SubPlatformList returns the sub-platforms in detection order
+func (f *Architectury) SubPlatformList() []develop.DevPlatform {
+	a := make([]develop.DevPlatform, 0, len(f.SubPlatforms))
+	for _, i := range Platforms {
+		if _, ok := f.SubPlatforms[i]; ok {
+			a = append(a, i)
+		}
+	}
+	return a
+}
+
 func (f *Architectury) SubPlatformNames() []string {
 	a := make([]string, len(f.SubPlatforms))
 	z := 0
@@ -149,3 +197,12 @@ func (f *Architectury) fetchArchApi() (err error) {
 	})
 	return err
 }
+
+func (f *Architectury) fetchArchLoom() (err error) {
+	f.Meta.Loom, err = genericPlatformFetch[meta.ArchitecturyLoomMeta](f.Conf.Loom, utils.PathJoin(f.Cache, "loom.xml"), func(r io.Reader, m *meta.ArchitecturyLoomMeta) error {
+		return xml.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.ArchitecturyLoomMeta) error {
+		return xml.NewEncoder(w).Encode(m)
+	})
+	return err
+}
@@ -0,0 +1,135 @@
+package dev
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"github.com/magiconair/properties"
+	"github.com/mrmelon54/mcmodupdater/config"
+	"github.com/mrmelon54/mcmodupdater/develop"
+	"github.com/mrmelon54/mcmodupdater/meta"
+	"github.com/mrmelon54/mcmodupdater/meta/shared"
+	"github.com/mrmelon54/mcmodupdater/utils"
+	"io"
+	"io/fs"
+)
+
+var (
+	PlatformBabric = develop.DevPlatform{Name: "Babric"}
+	// babricMinecraftVersion is the only Minecraft version Babric supports
+	babricMinecraftVersion = "b1.7.3"
+	// words in the build files of Babric projects, these share the
+	// fabric.mod.json file with Fabric projects
+	babricBuildWords = []string{"babric", "glass-launcher", "glasslauncher", "biny"}
+)
+
+type Babric struct {
+	Conf  config.BabricDevelopConfig
+	Meta  *BabricMeta
+	Cache string
+}
+
+type BabricMeta struct {
+	done     chan struct{}
+	Loader   meta.BabricLoaderMeta
+	Mappings meta.BabricMappingsMeta
+}
+
+func ForBabric(conf config.DevelopConfig, cache string) develop.Develop {
+	return &Babric{
+		Conf:  conf.Babric,
+		Meta:  &BabricMeta{},
+		Cache: utils.PathJoin(cache, "babric"),
+	}
+}
+
+func (f *Babric) Platform() develop.DevPlatform {
+	return PlatformBabric
+}
+
+func (f *Babric) FetchCalls() []develop.DevFetch {
+	return []develop.DevFetch{
+		{"Loader", f.FetchLoader},
+		{"Mappings", f.FetchMappings},
+	}
+}
+
+func (f *Babric) ValidTree(tree fs.FS) bool {
+	_, ok := genericCheckOnePathExists(tree, fabricLoaderMetaPaths...)
+	return ok && isBabricTree(tree)
+}
+
+func isBabricTree(tree fs.FS) bool {
+	return genericBuildContains(tree, babricBuildWords...)
+}
+
+func (f *Babric) ReadVersionFile(tree fs.FS, name string) (map[develop.PropVersion]string, error) {
+	if name == "" {
+		name = "gradle.properties"
+	}
+	gradlePropFile, err := tree.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("open gradle.properties: %w", err)
+	}
+	gradlePropContent, err := io.ReadAll(gradlePropFile)
+	if err != nil {
+		return nil, fmt.Errorf("read gradle.properties: %w", err)
+	}
+	prop, err := properties.Load(gradlePropContent, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	propM := prop.Map()
+	a := make(map[develop.PropVersion]string)
+	mapProp(a, develop.ModVersion, propM)
+	mapProp(a, develop.MinecraftVersion, propM)
+	mapProp(a, develop.YarnMappingsVersion, propM)
+	mapProp(a, develop.FabricLoaderVersion, propM)
+	mapPluginVersions(a, tree)
+	return a, nil
+}
+
+func (f *Babric) LatestVersion(prop develop.PropVersion, mcVersion string) (string, bool) {
+	if mcVersion != babricMinecraftVersion {
+		return "", false
+	}
+	switch prop {
+	case develop.FabricLoaderVersion:
+		a, err := f.LatestLoaderVersion(mcVersion)
+		return a, err == nil
+	case develop.YarnMappingsVersion:
+		return shared.LatestPrefixMavenVersion(shared.MavenMeta(f.Meta.Mappings), mcVersion+"+")
+	default:
+	}
+	return "", false
+}
+
+func (f *Babric) LatestLoaderVersion(_ string) (string, error) {
+	err := f.FetchLoader()
+	if err != nil {
+		return "", err
+	}
+	if len(f.Meta.Loader) < 1 {
+		return "", fmt.Errorf("no babric loaders found")
+	}
+	return f.Meta.Loader[0].Version, nil
+}
+
+func (f *Babric) FetchLoader() (err error) {
+	f.Meta.Loader, err = genericPlatformFetch[meta.BabricLoaderMeta](f.Conf.Loader, utils.PathJoin(f.Cache, "loader.json"), func(r io.Reader, m *meta.BabricLoaderMeta) error {
+		return json.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.BabricLoaderMeta) error {
+		return json.NewEncoder(w).Encode(m)
+	})
+	return err
+}
+
+func (f *Babric) FetchMappings() (err error) {
+	f.Meta.Mappings, err = genericPlatformFetch[meta.BabricMappingsMeta](f.Conf.Mappings, utils.PathJoin(f.Cache, "mappings.xml"), func(r io.Reader, m *meta.BabricMappingsMeta) error {
+		return xml.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.BabricMappingsMeta) error {
+		return xml.NewEncoder(w).Encode(m)
+	})
+	return err
+}
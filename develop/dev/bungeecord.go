@@ -0,0 +1,117 @@
+package dev
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/mrmelon54/mcmodupdater/config"
+	"github.com/mrmelon54/mcmodupdater/develop"
+	"github.com/mrmelon54/mcmodupdater/meta"
+	"github.com/mrmelon54/mcmodupdater/meta/shared"
+	"github.com/mrmelon54/mcmodupdater/utils"
+	"io"
+	"io/fs"
+)
+
+var (
+	PlatformBungeeCord        = develop.DevPlatform{Name: "BungeeCord"}
+	bungeeCordLoaderMetaPaths = []string{
+		"src/main/resources/bungee.yml",
+		"resources/bungee.yml",
+	}
+)
+
+// BungeeCord is used for BungeeCord and Waterfall plugins
+type BungeeCord struct {
+	Conf  config.BungeeCordDevelopConfig
+	Meta  *BungeeCordMeta
+	Cache string
+}
+
+func ForBungeeCord(conf config.DevelopConfig, cache string) develop.Develop {
+	return &BungeeCord{
+		Conf:  conf.BungeeCord,
+		Meta:  &BungeeCordMeta{},
+		Cache: utils.PathJoin(cache, "bungeecord"),
+	}
+}
+
+type BungeeCordMeta struct {
+	done         chan struct{}
+	Api          meta.BungeeCordApiMeta
+	WaterfallApi meta.WaterfallApiMeta
+}
+
+func (b *BungeeCord) Platform() develop.DevPlatform {
+	return PlatformBungeeCord
+}
+
+func (b *BungeeCord) FetchCalls() []develop.DevFetch {
+	return []develop.DevFetch{
+		{"API", b.FetchApi},
+		{"Waterfall API", b.FetchWaterfallApi},
+	}
+}
+
+func (b *BungeeCord) ValidTree(tree fs.FS) bool {
+	_, ok := genericCheckOnePathExists(tree, bungeeCordLoaderMetaPaths...)
+	return ok
+}
+
+func (b *BungeeCord) ReadVersionFile(tree fs.FS, name string) (map[develop.PropVersion]string, error) {
+	propM, err := genericReadOptionalProps(tree, name)
+	if err != nil {
+		return nil, err
+	}
+
+	a := make(map[develop.PropVersion]string)
+	mapProp(a, develop.ModVersion, propM)
+	mapProp(a, develop.BungeeCordApiVersion, propM)
+	mapProp(a, develop.WaterfallApiVersion, propM)
+	mapPluginVersions(a, tree)
+	return a, nil
+}
+
+// LatestVersion ignores the Minecraft version, proxies support many versions
+// with the same API
+func (b *BungeeCord) LatestVersion(prop develop.PropVersion, _ string) (string, bool) {
+	switch prop {
+	case develop.BungeeCordApiVersion:
+		a, err := b.LatestLoaderVersion("")
+		return a, err == nil
+	case develop.WaterfallApiVersion:
+		return shared.LatestChannelMavenVersion(shared.MavenMeta(b.Meta.WaterfallApi), b.Conf.ApiChannel)
+	default:
+	}
+	return "", false
+}
+
+// LatestLoaderVersion returns the latest API version
+func (b *BungeeCord) LatestLoaderVersion(_ string) (string, error) {
+	err := b.FetchApi()
+	if err != nil {
+		return "", err
+	}
+	version, ok := shared.LatestChannelMavenVersion(shared.MavenMeta(b.Meta.Api), b.Conf.ApiChannel)
+	if !ok {
+		return "", fmt.Errorf("no bungeecord api versions found")
+	}
+	return version, nil
+}
+
+func (b *BungeeCord) FetchApi() (err error) {
+	b.Meta.Api, err = genericPlatformFetch[meta.BungeeCordApiMeta](b.Conf.Api, utils.PathJoin(b.Cache, "api.xml"), func(r io.Reader, m *meta.BungeeCordApiMeta) error {
+		return xml.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.BungeeCordApiMeta) error {
+		return xml.NewEncoder(w).Encode(m)
+	})
+	return err
+}
+
+func (b *BungeeCord) FetchWaterfallApi() (err error) {
+	b.Meta.WaterfallApi, err = genericPlatformFetch[meta.WaterfallApiMeta](b.Conf.WaterfallApi, utils.PathJoin(b.Cache, "waterfall-api.xml"), func(r io.Reader, m *meta.WaterfallApiMeta) error {
+		return xml.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.WaterfallApiMeta) error {
+		return xml.NewEncoder(w).Encode(m)
+	})
+	return err
+}
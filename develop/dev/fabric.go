@@ -34,6 +34,7 @@ type FabricMeta struct {
 	Yarn   meta.FabricYarnMeta
 	Loader meta.FabricLoaderMeta
 	Api    meta.FabricApiMeta
+	Loom   meta.FabricLoomMeta
 }
 
 func ForFabric(conf config.DevelopConfig, cache string) develop.Develop {
@@ -54,12 +55,13 @@ func (f *Fabric) FetchCalls() []develop.DevFetch {
 		{"Yarn", f.FetchYarn},
 		{"Loader", f.FetchLoader},
 		{"API", f.FetchApi},
+		{"Loom", f.FetchLoom},
 	}
 }
 
 func (f *Fabric) ValidTree(tree fs.FS) bool {
 	_, ok := genericCheckOnePathExists(tree, fabricLoaderMetaPaths...)
-	return ok
+	return ok && !isLegacyFabricTree(tree) && !isBabricTree(tree) && !isOrnitheTree(tree)
 }
 
 func (f *Fabric) ReadVersionFile(tree fs.FS, name string) (map[develop.PropVersion]string, error) {
@@ -86,6 +88,8 @@ func (f *Fabric) ReadVersionFile(tree fs.FS, name string) (map[develop.PropVersi
 	mapProp(a, develop.YarnMappingsVersion, propM)
 	mapProp(a, develop.FabricLoaderVersion, propM)
 	mapProp(a, develop.FabricApiVersion, propM)
+	mapProp(a, develop.LoomVersion, propM)
+	mapPluginVersions(a, tree)
 	return a, nil
 }
 
@@ -102,6 +106,8 @@ func (f *Fabric) LatestVersion(prop develop.PropVersion, mcVersion string) (stri
 		if a, ok := shared.LatestYarnVersion(f.Meta.Yarn, mcVersion); ok {
 			return a.Version, ok
 		}
+	case develop.LoomVersion:
+		return shared.LatestChannelMavenVersion(shared.MavenMeta(f.Meta.Loom), f.Conf.LoomChannel)
 	default:
 	}
 	return "", false
@@ -153,3 +159,12 @@ func (f *Fabric) FetchApi() (err error) {
 	})
 	return err
 }
+
+func (f *Fabric) FetchLoom() (err error) {
+	f.Meta.Loom, err = genericPlatformFetch[meta.FabricLoomMeta](f.Conf.Loom, utils.PathJoin(f.Cache, "loom.xml"), func(r io.Reader, m *meta.FabricLoomMeta) error {
+		return xml.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.FabricLoomMeta) error {
+		return xml.NewEncoder(w).Encode(m)
+	})
+	return err
+}
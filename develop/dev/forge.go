@@ -1,16 +1,21 @@
 package dev
 
 import (
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"github.com/Masterminds/semver/v3"
 	"github.com/magiconair/properties"
 	"github.com/mrmelon54/mcmodupdater/config"
 	"github.com/mrmelon54/mcmodupdater/develop"
+	"github.com/mrmelon54/mcmodupdater/edit"
 	"github.com/mrmelon54/mcmodupdater/meta"
 	"github.com/mrmelon54/mcmodupdater/meta/shared"
 	"github.com/mrmelon54/mcmodupdater/utils"
 	"io"
 	"io/fs"
+	"strconv"
+	"strings"
 )
 
 var (
@@ -19,6 +24,11 @@ var (
 		"src/main/resources/META-INF/mods.toml",
 		"resources/META-INF/mods.toml",
 	}
+	// projects before Minecraft 1.13 use mcmod.info
+	forgeLegacyMetaPaths = []string{
+		"src/main/resources/mcmod.info",
+		"resources/mcmod.info",
+	}
 )
 
 type Forge struct {
@@ -36,8 +46,12 @@ func ForForge(conf config.DevelopConfig, cache string) develop.Develop {
 }
 
 type ForgeMeta struct {
-	done chan struct{}
-	Api  meta.ForgeApiMeta
+	done        chan struct{}
+	Api         meta.ForgeApiMeta
+	ForgeGradle meta.ForgeGradleMeta
+	McpStable   meta.ForgeMcpMeta
+	McpSnapshot meta.ForgeMcpMeta
+	Promotions  meta.ForgePromotionsMeta
 }
 
 func (f *Forge) Platform() develop.DevPlatform {
@@ -47,12 +61,14 @@ func (f *Forge) Platform() develop.DevPlatform {
 func (f *Forge) FetchCalls() []develop.DevFetch {
 	return []develop.DevFetch{
 		{"API", f.FetchApi},
+		{"Promotions", f.FetchPromotions},
+		{"ForgeGradle", f.FetchForgeGradle},
 	}
 }
 
 func (f *Forge) ValidTree(tree fs.FS) bool {
-	_, ok := genericCheckOnePathExists(tree, forgeLoaderMetaPaths...)
-	return ok
+	_, ok := genericCheckOnePathExists(tree, append(forgeLoaderMetaPaths, forgeLegacyMetaPaths...)...)
+	return ok && !isNeoForgeTree(tree)
 }
 
 func (f *Forge) ReadVersionFile(tree fs.FS, name string) (map[develop.PropVersion]string, error) {
@@ -78,31 +94,172 @@ func (f *Forge) ReadVersionFile(tree fs.FS, name string) (map[develop.PropVersio
 	mapProp(a, develop.MinecraftVersion, propM)
 	mapProp(a, develop.ForgeVersion, propM)
 	mapProp(a, develop.ForgeMappingsVersion, propM)
+	mapProp(a, develop.ForgeGradleVersion, propM)
+	mapPluginVersions(a, tree)
+	mapLegacyForgeBlock(a, tree)
 	return a, nil
 }
 
+// mapLegacyForgeBlock reads the versions from the minecraft block used by
+// ForgeGradle 2, properties from the properties file take priority
+func mapLegacyForgeBlock(out map[develop.PropVersion]string, tree fs.FS) {
+	f, err := tree.Open("build.gradle")
+	if err != nil {
+		return
+	}
+	//goland:noinspection GoUnhandledErrorResult
+	defer f.Close()
+	block, err := edit.ReadLegacyForgeBlock(f)
+	if err != nil {
+		return
+	}
+	if mc, _, ok := strings.Cut(block.Version, "-"); ok {
+		setIfMissing(out, develop.MinecraftVersion, mc)
+		setIfMissing(out, develop.ForgeVersion, block.Version)
+	}
+	setIfMissing(out, develop.ForgeMappingsVersion, block.Mappings)
+	setIfMissing(out, develop.ForgeMappingsVersion, block.McpVersion)
+}
+
+func setIfMissing(out map[develop.PropVersion]string, target develop.PropVersion, value string) {
+	if _, ok := out[target]; !ok && value != "" {
+		out[target] = value
+	}
+}
+
 func (f *Forge) LatestVersion(prop develop.PropVersion, mcVersion string) (string, bool) {
 	switch prop {
 	case develop.ForgeVersion:
 		a, err := f.LatestLoaderVersion(mcVersion)
 		return a, err == nil
+	case develop.ForgeMappingsVersion:
+		a, err := f.LatestMcpMappings(mcVersion)
+		return a, err == nil
+	case develop.ForgeGradleVersion:
+		return shared.LatestChannelMavenVersion(shared.MavenMeta(f.Meta.ForgeGradle), f.Conf.ForgeGradleChannel)
 	default:
 	}
 	return "", false
 }
 
 func (f *Forge) LatestLoaderVersion(mcVersion string) (string, error) {
+	return f.LatestPromotedVersion(mcVersion, f.Conf.Promotion)
+}
+
+// LatestPromotedVersion returns the "recommended" or "latest" promoted build
+// for the Minecraft version, recommended falls back to latest and versions
+// without promotions use the maven metadata ordering
+func (f *Forge) LatestPromotedVersion(mcVersion, promotion string) (string, error) {
+	if !IsForgePromotion(promotion) {
+		return "", fmt.Errorf("unknown forge promotion '%s'", promotion)
+	}
 	err := f.FetchApi()
 	if err != nil {
 		return "", err
 	}
-	version, ok := shared.LatestForgeMavenVersion(shared.MavenMeta(f.Meta.Api), mcVersion)
+	api := shared.MavenMeta(f.Meta.Api)
+
+	promotions := []string{promotion}
+	if promotion != ForgePromotionLatest {
+		promotions = append(promotions, ForgePromotionLatest)
+	}
+	if f.FetchPromotions() == nil {
+		for _, i := range promotions {
+			if a, ok := f.Meta.Promotions.Promos[mcVersion+"-"+i]; ok {
+				return forgePromotedMavenVersion(api, mcVersion, a), nil
+			}
+		}
+	}
+
+	version, ok := shared.LatestForgeMavenVersion(api, mcVersion)
 	if !ok {
 		return "", fmt.Errorf("no forge loaders found")
 	}
 	return version, nil
 }
 
+// Forge promotions in promotions_slim.json
+const (
+	ForgePromotionRecommended = "recommended"
+	ForgePromotionLatest      = "latest"
+)
+
+// ForgePromotionKey is the property used by projects to choose the promotion,
+// this is an option rather than a version
+const ForgePromotionKey = "forge_promotion"
+
+// IsForgePromotion reports whether the promotion is known
+func IsForgePromotion(promotion string) bool {
+	return promotion == ForgePromotionRecommended || promotion == ForgePromotionLatest
+}
+
+// forgePromotedMavenVersion finds the maven version for a promoted build, old
+// versions have the Minecraft version on both ends like
+// "1.7.10-10.13.4.1614-1.7.10"
+func forgePromotedMavenVersion(api shared.MavenMeta, mcVersion, build string) string {
+	prefix := mcVersion + "-" + build
+	for _, i := range api.Versioning.Versions.Version {
+		if i == prefix || strings.HasPrefix(i, prefix+"-") {
+			return i
+		}
+	}
+	return prefix
+}
+
+// LatestMcpMappings returns the MCP mappings for legacy Minecraft versions in
+// the "stable_39" format used by ForgeGradle 2
+func (f *Forge) LatestMcpMappings(mcVersion string) (string, error) {
+	if !isLegacyForge(mcVersion) {
+		return "", fmt.Errorf("mcp mappings are only resolved before Minecraft 1.13")
+	}
+	if f.Conf.McpChannel != "snapshot" {
+		if err := f.FetchMcpStable(); err != nil {
+			return "", err
+		}
+		if a, ok := latestMcpVersion(shared.MavenMeta(f.Meta.McpStable), mcVersion); ok {
+			return "stable_" + a, nil
+		}
+	}
+	if err := f.FetchMcpSnapshot(); err != nil {
+		return "", err
+	}
+	if a, ok := latestMcpVersion(shared.MavenMeta(f.Meta.McpSnapshot), mcVersion); ok {
+		return "snapshot_" + a, nil
+	}
+	return "", fmt.Errorf("no mcp mappings found")
+}
+
+// latestMcpVersion finds the highest "39-1.12" style version for the
+// Minecraft version or its major version and returns the first part
+func latestMcpVersion(m shared.MavenMeta, mcVersion string) (string, bool) {
+	suffixes := []string{"-" + mcVersion}
+	if v, err := semver.NewVersion(mcVersion); err == nil {
+		suffixes = append(suffixes, fmt.Sprintf("-%d.%d", v.Major(), v.Minor()))
+	}
+	for _, suffix := range suffixes {
+		var latest string
+		var latestN int
+		for _, i := range m.Versioning.Versions.Version {
+			a, ok := strings.CutSuffix(i, suffix)
+			if !ok {
+				continue
+			}
+			if n, err := strconv.Atoi(a); err == nil && n > latestN {
+				latest, latestN = a, n
+			}
+		}
+		if latest != "" {
+			return latest, true
+		}
+	}
+	return "", false
+}
+
+func isLegacyForge(mcVersion string) bool {
+	v, err := semver.NewVersion(mcVersion)
+	return err == nil && v.Major() == 1 && v.Minor() < 13
+}
+
 func (f *Forge) FetchApi() (err error) {
 	f.Meta.Api, err = genericPlatformFetch[meta.ForgeApiMeta](f.Conf.Api, utils.PathJoin(f.Cache, "api.xml"), func(r io.Reader, m *meta.ForgeApiMeta) error {
 		return xml.NewDecoder(r).Decode(m)
@@ -111,3 +268,39 @@ func (f *Forge) FetchApi() (err error) {
 	})
 	return err
 }
+
+func (f *Forge) FetchPromotions() (err error) {
+	f.Meta.Promotions, err = genericPlatformFetch[meta.ForgePromotionsMeta](f.Conf.Promotions, utils.PathJoin(f.Cache, "promotions.json"), func(r io.Reader, m *meta.ForgePromotionsMeta) error {
+		return json.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.ForgePromotionsMeta) error {
+		return json.NewEncoder(w).Encode(m)
+	})
+	return err
+}
+
+func (f *Forge) FetchForgeGradle() (err error) {
+	f.Meta.ForgeGradle, err = genericPlatformFetch[meta.ForgeGradleMeta](f.Conf.ForgeGradle, utils.PathJoin(f.Cache, "forge-gradle.xml"), func(r io.Reader, m *meta.ForgeGradleMeta) error {
+		return xml.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.ForgeGradleMeta) error {
+		return xml.NewEncoder(w).Encode(m)
+	})
+	return err
+}
+
+func (f *Forge) FetchMcpStable() (err error) {
+	f.Meta.McpStable, err = genericPlatformFetch[meta.ForgeMcpMeta](f.Conf.McpStable, utils.PathJoin(f.Cache, "mcp-stable.xml"), func(r io.Reader, m *meta.ForgeMcpMeta) error {
+		return xml.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.ForgeMcpMeta) error {
+		return xml.NewEncoder(w).Encode(m)
+	})
+	return err
+}
+
+func (f *Forge) FetchMcpSnapshot() (err error) {
+	f.Meta.McpSnapshot, err = genericPlatformFetch[meta.ForgeMcpMeta](f.Conf.McpSnapshot, utils.PathJoin(f.Cache, "mcp-snapshot.xml"), func(r io.Reader, m *meta.ForgeMcpMeta) error {
+		return xml.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.ForgeMcpMeta) error {
+		return xml.NewEncoder(w).Encode(m)
+	})
+	return err
+}
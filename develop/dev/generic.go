@@ -1,33 +1,58 @@
 package dev
 
 import (
+	"bufio"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"github.com/komkom/toml"
+	"github.com/magiconair/properties"
 	"github.com/mrmelon54/mcmodupdater/config"
 	"github.com/mrmelon54/mcmodupdater/develop"
+	"github.com/mrmelon54/mcmodupdater/edit"
+	libVersion "github.com/mrmelon54/mcmodupdater/meta/quilt/lib-version"
 	"io"
 	"io/fs"
 	"net/http"
 	"os"
 	"path"
+	"strings"
 	"time"
 )
 
 var ErrOutdatedCache = errors.New("outdated cache")
 
 var (
+	// platforms are detected in this order, platforms sharing metadata files
+	// with another platform must come first
 	DevelopPlatformsFactory = []func(config.DevelopConfig, string) develop.Develop{
 		// Architectury MUST be handled separately
+		ForLegacyFabric,
+		ForBabric,
+		ForOrnithe,
 		ForFabric,
 		ForForge,
 		ForQuilt,
 		ForNeoForge,
-		//TODO: add LiteLoader options.. https://dl.liteloader.com/versions/versions.json
+		ForLiteLoader,
+		ForPaper,
+		ForVelocity,
+		ForBungeeCord,
+		ForSponge,
 	}
 	Platforms = []develop.DevPlatform{
+		PlatformLegacyFabric,
+		PlatformBabric,
+		PlatformOrnithe,
 		PlatformFabric,
 		PlatformForge,
 		PlatformQuilt,
 		PlatformNeoForge,
+		PlatformLiteLoader,
+		PlatformPaper,
+		PlatformVelocity,
+		PlatformBungeeCord,
+		PlatformSponge,
 	}
 )
 
@@ -87,6 +112,56 @@ func mapProp(out map[develop.PropVersion]string, target develop.PropVersion, in
 	}
 }
 
+// mapPluginVersions reads plugin and dependency versions from the build
+// scripts and version catalog, values already read from the properties file
+// are kept
+func mapPluginVersions(out map[develop.PropVersion]string, tree fs.FS) {
+	found := make(map[develop.PropVersion]string)
+	for _, i := range edit.GradleBuildPaths {
+		for _, read := range []func(io.Reader) (map[develop.PropVersion]string, error){edit.ReadGradlePlugins, edit.ReadGradleDependencies} {
+			f, err := tree.Open(i)
+			if err != nil {
+				break
+			}
+			a, err := read(f)
+			_ = f.Close()
+			if err != nil {
+				continue
+			}
+			for k, v := range a {
+				found[k] = v
+			}
+		}
+	}
+
+	if f, err := tree.Open(edit.GradleVersionCatalogPath); err == nil {
+		var v libVersion.LibVersion
+		if json.NewDecoder(toml.New(f)).Decode(&v) == nil {
+			for _, i := range v.Plugins {
+				if p, ok := develop.PropVersionFromPluginId(i.ID); ok {
+					if a := i.Version.Resolve(v.Versions); a != "" && !edit.IsDynamicVersion(a) {
+						found[p] = a
+					}
+				}
+			}
+			for _, i := range v.Libraries {
+				if p, ok := develop.PropVersionFromModule(i.ModuleId()); ok {
+					if a := i.Version.Resolve(v.Versions); a != "" && !edit.IsDynamicVersion(a) {
+						found[p] = a
+					}
+				}
+			}
+		}
+		_ = f.Close()
+	}
+
+	for k, v := range found {
+		if _, ok := out[k]; !ok {
+			out[k] = v
+		}
+	}
+}
+
 func genericCheckOnePathExists(tree fs.FS, name ...string) (string, bool) {
 	for _, i := range name {
 		if genericCheckPathExists(tree, i) {
@@ -100,3 +175,103 @@ func genericCheckPathExists(tree fs.FS, name string) bool {
 	_, err := fs.Stat(tree, name)
 	return err == nil
 }
+
+// genericBuildContains reports whether the build scripts or properties file
+// mention any of the words, this is used to tell apart platforms which share
+// the same mod metadata file
+func genericBuildContains(tree fs.FS, words ...string) bool {
+	return genericBuildMatches(tree, func(s string) bool {
+		for _, i := range words {
+			if strings.Contains(s, i) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// genericBuildMatches calls match with the lowercase contents of the build
+// scripts and properties file until it returns true
+func genericBuildMatches(tree fs.FS, match func(s string) bool) bool {
+	names := append([]string{"gradle.properties"}, edit.GradleBuildPaths...)
+	for _, i := range names {
+		b, err := fs.ReadFile(tree, i)
+		if err != nil {
+			continue
+		}
+		if match(strings.ToLower(string(b))) {
+			return true
+		}
+	}
+	return false
+}
+
+// genericReadOptionalProps reads the properties file, plugin projects often
+// don't have one so a missing file is empty
+func genericReadOptionalProps(tree fs.FS, name string) (map[string]string, error) {
+	if name == "" {
+		name = "gradle.properties"
+	}
+	gradlePropContent, err := fs.ReadFile(tree, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read gradle.properties: %w", err)
+	}
+	prop, err := properties.Load(gradlePropContent, 0)
+	if err != nil {
+		return nil, err
+	}
+	return prop.Map(), nil
+}
+
+// genericSourceImports reports whether any Java or Kotlin source file imports
+// the class, this is used for platforms detected from annotations. Only the
+// header of each file is read and the walk stops at the first match.
+func genericSourceImports(tree fs.FS, class string) bool {
+	found := false
+	for _, i := range []string{"src/main/java", "src/main/kotlin"} {
+		_ = fs.WalkDir(tree, i, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || found {
+				return fs.SkipAll
+			}
+			if d.IsDir() || (path.Ext(p) != ".java" && path.Ext(p) != ".kt") {
+				return nil
+			}
+			if sourceFileImports(tree, p, class) {
+				found = true
+				return fs.SkipAll
+			}
+			return nil
+		})
+	}
+	return found
+}
+
+// sourceFileImports reads the package and import lines of the source file,
+// stopping at the first declaration
+func sourceFileImports(tree fs.FS, name, class string) bool {
+	f, err := tree.Open(name)
+	if err != nil {
+		return false
+	}
+	//goland:noinspection GoUnhandledErrorResult
+	defer f.Close()
+	wildcard := class[:strings.LastIndex(class, ".")+1] + "*"
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		t := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(t, "import "):
+			imp := strings.TrimSuffix(strings.TrimSpace(strings.TrimPrefix(t, "import ")), ";")
+			if imp == class || imp == wildcard {
+				return true
+			}
+		case t == "", strings.HasPrefix(t, "package "), strings.HasPrefix(t, "//"), strings.HasPrefix(t, "/*"), strings.HasPrefix(t, "*"):
+		default:
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,266 @@
+package dev
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Masterminds/semver/v3"
+	"github.com/mrmelon54/mcmodupdater/config"
+	"github.com/mrmelon54/mcmodupdater/develop"
+	"github.com/mrmelon54/mcmodupdater/meta"
+	"github.com/mrmelon54/mcmodupdater/utils"
+	"io"
+	"net/http"
+	"strings"
+)
+
+var PlatformGradle = develop.DevPlatform{Name: "Gradle"}
+
+// Gradle strategies for choosing the wrapper version
+const (
+	GradleStrategyLatest  = "latest"
+	GradleStrategyMinimum = "minimum"
+)
+
+// gradleMinimumVersions maps plugin versions to the minimum gradle version
+// they require, each list is sorted by plugin version. The requirements come
+// from the release notes of each plugin:
+//   - https://github.com/FabricMC/fabric-loom/releases
+//   - https://github.com/architectury/architectury-loom/releases
+//   - https://github.com/MinecraftForge/ForgeGradle/releases
+//   - https://github.com/neoforged/NeoGradle/releases
+//   - https://github.com/neoforged/ModDevGradle/releases
+var gradleMinimumVersions = map[develop.PropVersion][][2]string{
+	develop.LoomVersion: {
+		{"1.0", "7.3"},
+		{"1.3", "8.1"},
+		{"1.4", "8.3"},
+		{"1.5", "8.4"},
+		{"1.6", "8.6"},
+		{"1.7", "8.8"},
+		{"1.8", "8.10"},
+		{"1.9", "8.12"},
+		{"1.10", "8.14"},
+	},
+	develop.ArchitecturyLoomVersion: {
+		{"1.0", "7.3"},
+		{"1.3", "8.1"},
+		{"1.4", "8.3"},
+		{"1.5", "8.4"},
+		{"1.6", "8.6"},
+		{"1.7", "8.8"},
+		{"1.9", "8.12"},
+	},
+	develop.ForgeGradleVersion: {
+		{"5.1", "7.3"},
+		{"6.0", "8.1"},
+	},
+	develop.NeoGradleVersion: {
+		{"7.0", "8.4"},
+	},
+	develop.ModDevGradleVersion: {
+		{"1.0", "8.8"},
+		{"2.0", "8.10"},
+	},
+}
+
+// gradleMaximumVersions maps plugin versions to the first gradle version they
+// don't support, an empty bound means no known limit. Each list is sorted by
+// plugin version and uses the same sources as gradleMinimumVersions.
+var gradleMaximumVersions = map[develop.PropVersion][][2]string{
+	develop.LoomVersion: {
+		{"0.1", "9.0"},
+		{"1.11", ""},
+	},
+	develop.ArchitecturyLoomVersion: {
+		{"0.1", "9.0"},
+		{"1.11", ""},
+	},
+	develop.ForgeGradleVersion: {
+		{"5.0", "8.0"},
+		{"6.0", "9.0"},
+		{"7.0", ""},
+	},
+	develop.NeoGradleVersion: {
+		{"7.0", "9.0"},
+	},
+}
+
+// Gradle resolves versions for the gradle wrapper, this isn't a platform and
+// is used by every project with a gradle wrapper
+type Gradle struct {
+	Conf  config.GradleDevelopConfig
+	Meta  *GradleMeta
+	Cache string
+}
+
+type GradleMeta struct {
+	Versions meta.GradleVersionsMeta
+}
+
+func ForGradle(conf config.DevelopConfig, cache string) *Gradle {
+	return &Gradle{
+		Conf:  conf.Gradle,
+		Meta:  &GradleMeta{},
+		Cache: utils.PathJoin(cache, "gradle"),
+	}
+}
+
+func (g *Gradle) FetchCalls() []develop.DevFetch {
+	return []develop.DevFetch{
+		{"Gradle", g.FetchVersions},
+	}
+}
+
+// MinimumVersion returns the minimum gradle version required by the plugin
+// versions, an empty string means there are no known requirements
+func (g *Gradle) MinimumVersion(plugins map[develop.PropVersion]string) string {
+	var minimum *semver.Version
+	for prop, table := range gradleMinimumVersions {
+		pv, err := semver.NewVersion(plugins[prop])
+		if err != nil {
+			continue
+		}
+		// ignore the pre-release so "1.5-SNAPSHOT" matches "1.5"
+		base, _ := pv.SetPrerelease("")
+		for _, i := range table {
+			if base.LessThan(semver.MustParse(i[0])) {
+				break
+			}
+			gv := semver.MustParse(i[1])
+			if minimum == nil || gv.GreaterThan(minimum) {
+				minimum = gv
+			}
+		}
+	}
+	if minimum == nil {
+		return ""
+	}
+	return minimum.Original()
+}
+
+// MaximumVersion returns the first gradle version which isn't supported by
+// the plugin versions, an empty string means there is no known limit
+func (g *Gradle) MaximumVersion(plugins map[develop.PropVersion]string) string {
+	var maximum *semver.Version
+	for prop, table := range gradleMaximumVersions {
+		pv, err := semver.NewVersion(plugins[prop])
+		if err != nil {
+			continue
+		}
+		base, _ := pv.SetPrerelease("")
+		bound := ""
+		for _, i := range table {
+			if base.LessThan(semver.MustParse(i[0])) {
+				break
+			}
+			bound = i[1]
+		}
+		if bound == "" {
+			continue
+		}
+		gv := semver.MustParse(bound)
+		if maximum == nil || gv.LessThan(maximum) {
+			maximum = gv
+		}
+	}
+	if maximum == nil {
+		return ""
+	}
+	return maximum.Original()
+}
+
+// LatestVersion chooses the wrapper version using the configured strategy,
+// the latest strategy stays below the versions the plugins don't support and
+// the minimum strategy only changes versions older than the requirements
+func (g *Gradle) LatestVersion(current string, plugins map[develop.PropVersion]string) (string, bool) {
+	releases := make([]*semver.Version, 0, len(g.Meta.Versions))
+	for _, i := range g.Meta.Versions {
+		if !i.IsRelease() {
+			continue
+		}
+		if v, err := semver.NewVersion(i.Version); err == nil {
+			releases = append(releases, v)
+		}
+	}
+	if len(releases) == 0 {
+		return "", false
+	}
+
+	if g.Conf.Strategy == GradleStrategyLatest {
+		var maxVer *semver.Version
+		if maximum := g.MaximumVersion(plugins); maximum != "" {
+			maxVer = semver.MustParse(maximum)
+		}
+		var latest *semver.Version
+		for _, i := range releases {
+			if maxVer != nil && !i.LessThan(maxVer) {
+				continue
+			}
+			if latest == nil || i.GreaterThan(latest) {
+				latest = i
+			}
+		}
+		if latest == nil {
+			return "", false
+		}
+		return latest.Original(), true
+	}
+
+	minimum := g.MinimumVersion(plugins)
+	if minimum == "" {
+		return "", false
+	}
+	minVer := semver.MustParse(minimum)
+	if cv, err := semver.NewVersion(current); err == nil && !cv.LessThan(minVer) {
+		return current, true
+	}
+	var lowest *semver.Version
+	for _, i := range releases {
+		if !i.LessThan(minVer) && (lowest == nil || i.LessThan(lowest)) {
+			lowest = i
+		}
+	}
+	if lowest == nil {
+		return "", false
+	}
+	return lowest.Original(), true
+}
+
+// Checksum fetches the sha256 checksum of the distribution
+func (g *Gradle) Checksum(version, distType string) (string, error) {
+	var checksumUrl string
+	for _, i := range g.Meta.Versions {
+		if i.Version == version {
+			checksumUrl = i.ChecksumUrl
+		}
+	}
+	if checksumUrl == "" {
+		return "", fmt.Errorf("no checksum found for gradle %s", version)
+	}
+	if distType != "" && distType != "bin" {
+		checksumUrl = strings.Replace(checksumUrl, "-bin.zip", "-"+distType+".zip", 1)
+	}
+	resp, err := http.Get(checksumUrl)
+	if err != nil {
+		return "", err
+	}
+	//goland:noinspection GoUnhandledErrorResult
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetch gradle checksum: %s", resp.Status)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+func (g *Gradle) FetchVersions() (err error) {
+	g.Meta.Versions, err = genericPlatformFetch[meta.GradleVersionsMeta](g.Conf.Versions, utils.PathJoin(g.Cache, "versions.json"), func(r io.Reader, m *meta.GradleVersionsMeta) error {
+		return json.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.GradleVersionsMeta) error {
+		return json.NewEncoder(w).Encode(m)
+	})
+	return err
+}
@@ -0,0 +1,179 @@
+package dev
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"github.com/Masterminds/semver/v3"
+	"github.com/magiconair/properties"
+	"github.com/mrmelon54/mcmodupdater/config"
+	"github.com/mrmelon54/mcmodupdater/develop"
+	"github.com/mrmelon54/mcmodupdater/meta"
+	"github.com/mrmelon54/mcmodupdater/meta/shared"
+	"github.com/mrmelon54/mcmodupdater/utils"
+	"io"
+	"io/fs"
+)
+
+var (
+	PlatformLegacyFabric = develop.DevPlatform{Name: "Legacy Fabric"}
+	// words in the build files of Legacy Fabric projects, these share the
+	// fabric.mod.json file with Fabric projects
+	legacyFabricBuildWords = []string{"legacy-looming", "legacyfabric", "legacy-fabric", "legacy_fabric"}
+	// Minecraft versions supported by Legacy Fabric, newer versions use Fabric
+	legacyFabricMinVersion = semver.MustParse("1.3")
+	legacyFabricMaxVersion = semver.MustParse("1.13.2")
+)
+
+type LegacyFabric struct {
+	Conf  config.LegacyFabricDevelopConfig
+	Meta  *LegacyFabricMeta
+	Cache string
+}
+
+type LegacyFabricMeta struct {
+	done   chan struct{}
+	Game   meta.LegacyFabricGameMeta
+	Yarn   meta.LegacyFabricYarnMeta
+	Loader meta.LegacyFabricLoaderMeta
+	Api    meta.LegacyFabricApiMeta
+}
+
+func ForLegacyFabric(conf config.DevelopConfig, cache string) develop.Develop {
+	return &LegacyFabric{
+		Conf:  conf.LegacyFabric,
+		Meta:  &LegacyFabricMeta{},
+		Cache: utils.PathJoin(cache, "legacy-fabric"),
+	}
+}
+
+func (f *LegacyFabric) Platform() develop.DevPlatform {
+	return PlatformLegacyFabric
+}
+
+func (f *LegacyFabric) FetchCalls() []develop.DevFetch {
+	return []develop.DevFetch{
+		{"Game", f.FetchGame},
+		{"Yarn", f.FetchYarn},
+		{"Loader", f.FetchLoader},
+		{"API", f.FetchApi},
+	}
+}
+
+func (f *LegacyFabric) ValidTree(tree fs.FS) bool {
+	_, ok := genericCheckOnePathExists(tree, fabricLoaderMetaPaths...)
+	return ok && isLegacyFabricTree(tree)
+}
+
+func isLegacyFabricTree(tree fs.FS) bool {
+	return genericBuildContains(tree, legacyFabricBuildWords...)
+}
+
+func (f *LegacyFabric) ReadVersionFile(tree fs.FS, name string) (map[develop.PropVersion]string, error) {
+	if name == "" {
+		name = "gradle.properties"
+	}
+	gradlePropFile, err := tree.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("open gradle.properties: %w", err)
+	}
+	gradlePropContent, err := io.ReadAll(gradlePropFile)
+	if err != nil {
+		return nil, fmt.Errorf("read gradle.properties: %w", err)
+	}
+	prop, err := properties.Load(gradlePropContent, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	propM := prop.Map()
+	a := make(map[develop.PropVersion]string)
+	mapProp(a, develop.ModVersion, propM)
+	mapProp(a, develop.MinecraftVersion, propM)
+	mapProp(a, develop.YarnMappingsVersion, propM)
+	mapProp(a, develop.FabricLoaderVersion, propM)
+	mapProp(a, develop.LegacyFabricApiVersion, propM)
+	mapPluginVersions(a, tree)
+	return a, nil
+}
+
+func (f *LegacyFabric) LatestVersion(prop develop.PropVersion, mcVersion string) (string, bool) {
+	if !isLegacyFabricVersion(mcVersion) {
+		return "", false
+	}
+	switch prop {
+	case develop.FabricLoaderVersion:
+		a, err := f.LatestLoaderVersion(mcVersion)
+		return a, err == nil
+	case develop.LegacyFabricApiVersion:
+		if a, ok := shared.LatestMavenVersion(shared.MavenMeta(f.Meta.Api), mcVersion); ok {
+			return a, true
+		}
+		// newer releases support every Minecraft version at once
+		return shared.LatestChannelMavenVersion(shared.MavenMeta(f.Meta.Api), shared.ChannelStable)
+	case develop.YarnMappingsVersion:
+		if a, ok := shared.LatestYarnVersion(f.Meta.Yarn, mcVersion); ok {
+			return a.Version, ok
+		}
+	default:
+	}
+	return "", false
+}
+
+// isLegacyFabricVersion reports whether Legacy Fabric supports the Minecraft
+// version, pre-releases are compared using their release
+func isLegacyFabricVersion(mcVersion string) bool {
+	v, err := semver.NewVersion(mcVersion)
+	if err != nil {
+		return false
+	}
+	base, _ := v.SetPrerelease("")
+	return !base.LessThan(legacyFabricMinVersion) && !base.GreaterThan(legacyFabricMaxVersion)
+}
+
+func (f *LegacyFabric) LatestLoaderVersion(_ string) (string, error) {
+	err := f.FetchLoader()
+	if err != nil {
+		return "", err
+	}
+	if len(f.Meta.Loader) < 1 {
+		return "", fmt.Errorf("no legacy fabric loaders found")
+	}
+	return f.Meta.Loader[0].Version, nil
+}
+
+func (f *LegacyFabric) FetchGame() (err error) {
+	f.Meta.Game, err = genericPlatformFetch[meta.LegacyFabricGameMeta](f.Conf.Game, utils.PathJoin(f.Cache, "game.json"), func(r io.Reader, m *meta.LegacyFabricGameMeta) error {
+		return json.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.LegacyFabricGameMeta) error {
+		return json.NewEncoder(w).Encode(m)
+	})
+	return err
+}
+
+func (f *LegacyFabric) FetchYarn() (err error) {
+	f.Meta.Yarn, err = genericPlatformFetch[meta.LegacyFabricYarnMeta](f.Conf.Yarn, utils.PathJoin(f.Cache, "yarn.json"), func(r io.Reader, m *meta.LegacyFabricYarnMeta) error {
+		return json.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.LegacyFabricYarnMeta) error {
+		return json.NewEncoder(w).Encode(m)
+	})
+	return err
+}
+
+func (f *LegacyFabric) FetchLoader() (err error) {
+	f.Meta.Loader, err = genericPlatformFetch[meta.LegacyFabricLoaderMeta](f.Conf.Loader, utils.PathJoin(f.Cache, "loader.json"), func(r io.Reader, m *meta.LegacyFabricLoaderMeta) error {
+		return json.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.LegacyFabricLoaderMeta) error {
+		return json.NewEncoder(w).Encode(m)
+	})
+	return err
+}
+
+func (f *LegacyFabric) FetchApi() (err error) {
+	f.Meta.Api, err = genericPlatformFetch[meta.LegacyFabricApiMeta](f.Conf.Api, utils.PathJoin(f.Cache, "api.xml"), func(r io.Reader, m *meta.LegacyFabricApiMeta) error {
+		return xml.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.LegacyFabricApiMeta) error {
+		return xml.NewEncoder(w).Encode(m)
+	})
+	return err
+}
@@ -0,0 +1,160 @@
+package dev
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/magiconair/properties"
+	"github.com/mrmelon54/mcmodupdater/config"
+	"github.com/mrmelon54/mcmodupdater/develop"
+	"github.com/mrmelon54/mcmodupdater/meta"
+	"github.com/mrmelon54/mcmodupdater/utils"
+	"io"
+	"io/fs"
+	"sort"
+	"strconv"
+)
+
+var (
+	PlatformLiteLoader        = develop.DevPlatform{Name: "LiteLoader"}
+	liteLoaderLoaderMetaPaths = []string{
+		"src/main/resources/litemod.json",
+		"resources/litemod.json",
+	}
+)
+
+type LiteLoader struct {
+	Conf  config.LiteLoaderDevelopConfig
+	Meta  *LiteLoaderMeta
+	Cache string
+}
+
+func ForLiteLoader(conf config.DevelopConfig, cache string) develop.Develop {
+	return &LiteLoader{
+		Conf:  conf.LiteLoader,
+		Meta:  &LiteLoaderMeta{},
+		Cache: utils.PathJoin(cache, "liteloader"),
+	}
+}
+
+type LiteLoaderMeta struct {
+	done     chan struct{}
+	Versions meta.LiteLoaderVersionsMeta
+}
+
+func (l *LiteLoader) Platform() develop.DevPlatform {
+	return PlatformLiteLoader
+}
+
+func (l *LiteLoader) FetchCalls() []develop.DevFetch {
+	return []develop.DevFetch{
+		{"Versions", l.FetchVersions},
+	}
+}
+
+func (l *LiteLoader) ValidTree(tree fs.FS) bool {
+	_, ok := genericCheckOnePathExists(tree, liteLoaderLoaderMetaPaths...)
+	return ok
+}
+
+func (l *LiteLoader) ReadVersionFile(tree fs.FS, name string) (map[develop.PropVersion]string, error) {
+	if name == "" {
+		name = "gradle.properties"
+	}
+	gradlePropFile, err := tree.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("open gradle.properties: %w", err)
+	}
+	gradlePropContent, err := io.ReadAll(gradlePropFile)
+	if err != nil {
+		return nil, fmt.Errorf("read gradle.properties: %w", err)
+	}
+	prop, err := properties.Load(gradlePropContent, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	propM := prop.Map()
+	a := make(map[develop.PropVersion]string)
+	mapProp(a, develop.ModVersion, propM)
+	mapProp(a, develop.MinecraftVersion, propM)
+	mapProp(a, develop.LiteLoaderVersion, propM)
+	mapProp(a, develop.ForgeGradleVersion, propM)
+	mapPluginVersions(a, tree)
+	return a, nil
+}
+
+func (l *LiteLoader) LatestVersion(prop develop.PropVersion, mcVersion string) (string, bool) {
+	switch prop {
+	case develop.LiteLoaderVersion:
+		a, err := l.LatestLoaderVersion(mcVersion)
+		return a, err == nil
+	default:
+	}
+	return "", false
+}
+
+// LatestLoaderVersion returns the release build for the Minecraft version, the
+// snapshot build is used if there is no release or the channel is "snapshot"
+func (l *LiteLoader) LatestLoaderVersion(mcVersion string) (string, error) {
+	err := l.FetchVersions()
+	if err != nil {
+		return "", err
+	}
+	v, ok := l.Meta.Versions.Versions[mcVersion]
+	if !ok {
+		return "", fmt.Errorf("no liteloader builds found")
+	}
+	if l.Conf.Channel != "snapshot" {
+		if a, ok := latestLiteLoaderArtefact(v.Artefacts.LiteLoader); ok {
+			return a, nil
+		}
+	}
+	if a, ok := latestLiteLoaderArtefact(v.Snapshots.LiteLoader); ok {
+		return a, nil
+	}
+	return "", fmt.Errorf("no liteloader builds found")
+}
+
+// latestLiteLoaderArtefact uses the "latest" entry, falling back to the
+// highest build id
+func latestLiteLoaderArtefact(builds map[string]meta.LiteLoaderArtefactMeta) (string, bool) {
+	if a, ok := builds["latest"]; ok && a.Version != "" {
+		return a.Version, true
+	}
+	keys := make([]string, 0, len(builds))
+	for k, v := range builds {
+		if v.Version != "" {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return "", false
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return liteLoaderBuildLess(keys[i], keys[j])
+	})
+	return builds[keys[len(keys)-1]].Version, true
+}
+
+// liteLoaderBuildLess compares build ids numerically, ids which aren't
+// numbers sort before the numbered builds
+func liteLoaderBuildLess(a, b string) bool {
+	an, aErr := strconv.Atoi(a)
+	bn, bErr := strconv.Atoi(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		return an < bn
+	case aErr == nil || bErr == nil:
+		return bErr == nil
+	}
+	return a < b
+}
+
+func (l *LiteLoader) FetchVersions() (err error) {
+	l.Meta.Versions, err = genericPlatformFetch[meta.LiteLoaderVersionsMeta](l.Conf.Versions, utils.PathJoin(l.Cache, "versions.json"), func(r io.Reader, m *meta.LiteLoaderVersionsMeta) error {
+		return json.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.LiteLoaderVersionsMeta) error {
+		return json.NewEncoder(w).Encode(m)
+	})
+	return err
+}
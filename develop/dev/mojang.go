@@ -0,0 +1,88 @@
+package dev
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/mrmelon54/mcmodupdater/config"
+	"github.com/mrmelon54/mcmodupdater/develop"
+	"github.com/mrmelon54/mcmodupdater/meta"
+	"github.com/mrmelon54/mcmodupdater/utils"
+	"io"
+)
+
+var PlatformMojang = develop.DevPlatform{Name: "Mojang"}
+
+// Mojang reads the official version manifest for details about each
+// Minecraft version which aren't part of the mod loader metadata
+type Mojang struct {
+	Conf  config.MojangDevelopConfig
+	Meta  *MojangMeta
+	Cache string
+}
+
+type MojangMeta struct {
+	Manifest meta.MojangVersionManifest
+	Versions map[string]meta.MojangVersionMeta
+}
+
+func ForMojang(conf config.DevelopConfig, cache string) *Mojang {
+	return &Mojang{
+		Conf:  conf.Mojang,
+		Meta:  &MojangMeta{Versions: make(map[string]meta.MojangVersionMeta)},
+		Cache: utils.PathJoin(cache, "mojang"),
+	}
+}
+
+func (m *Mojang) FetchCalls() []develop.DevFetch {
+	return []develop.DevFetch{
+		{"Manifest", m.FetchManifest},
+	}
+}
+
+// Version fetches the per-version JSON for the Minecraft version
+func (m *Mojang) Version(mcVersion string) (meta.MojangVersionMeta, error) {
+	if v, ok := m.Meta.Versions[mcVersion]; ok {
+		return v, nil
+	}
+	var url string
+	for _, i := range m.Meta.Manifest.Versions {
+		if i.Id == mcVersion {
+			url = i.Url
+			break
+		}
+	}
+	if url == "" {
+		return meta.MojangVersionMeta{}, fmt.Errorf("unknown Minecraft version: %s", mcVersion)
+	}
+	v, err := genericPlatformFetch[meta.MojangVersionMeta](url, utils.PathJoin(m.Cache, "versions", mcVersion+".json"), func(r io.Reader, m *meta.MojangVersionMeta) error {
+		return json.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.MojangVersionMeta) error {
+		return json.NewEncoder(w).Encode(m)
+	})
+	if err != nil {
+		return meta.MojangVersionMeta{}, err
+	}
+	m.Meta.Versions[mcVersion] = v
+	return v, nil
+}
+
+// JavaVersion returns the major Java version required by the Minecraft version
+func (m *Mojang) JavaVersion(mcVersion string) (int, error) {
+	v, err := m.Version(mcVersion)
+	if err != nil {
+		return 0, err
+	}
+	if v.JavaVersion.MajorVersion == 0 {
+		return 0, fmt.Errorf("no java version found for %s", mcVersion)
+	}
+	return v.JavaVersion.MajorVersion, nil
+}
+
+func (m *Mojang) FetchManifest() (err error) {
+	m.Meta.Manifest, err = genericPlatformFetch[meta.MojangVersionManifest](m.Conf.Manifest, utils.PathJoin(m.Cache, "manifest.json"), func(r io.Reader, m *meta.MojangVersionManifest) error {
+		return json.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.MojangVersionManifest) error {
+		return json.NewEncoder(w).Encode(m)
+	})
+	return err
+}
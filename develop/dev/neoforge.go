@@ -3,6 +3,7 @@ package dev
 import (
 	"encoding/xml"
 	"fmt"
+	"github.com/Masterminds/semver/v3"
 	"github.com/magiconair/properties"
 	"github.com/mrmelon54/mcmodupdater/config"
 	"github.com/mrmelon54/mcmodupdater/develop"
@@ -11,14 +12,22 @@ import (
 	"io"
 	"io/fs"
 	"path"
+	"regexp"
 )
 
 var (
 	PlatformNeoForge        = develop.DevPlatform{Name: "NeoForge", Sub: "neoforge"}
 	neoForgeLoaderMetaPaths = []string{
-		"src/main/resources/META-INF/mods.toml",
-		"resources/META-INF/mods.toml",
+		"src/main/resources/META-INF/neoforge.mods.toml",
+		"resources/META-INF/neoforge.mods.toml",
 	}
+	// projects before 1.20.5 use mods.toml like Forge projects, these are told
+	// apart using the NeoForge dependency, properties or NeoGradle and
+	// ModDevGradle plugins. The ModDevGradle legacyforge plugin and the
+	// NeoForged maven are also used by Forge projects.
+	neoForgeBuildRe = regexp.MustCompile(`net\.neoforged:(?:neoforge|forge)\b|\bneo_version\b|\bneoforge_version\b|net\.neoforged\.gradle\b|net\.neoforged\.moddev(\.legacyforge)?`)
+	// the last Minecraft version using the net.neoforged:forge artifact
+	neoForgeLegacyMinecraft = semver.MustParse("1.20.1")
 )
 
 type NeoForge struct {
@@ -36,8 +45,11 @@ func ForNeoForge(conf config.DevelopConfig, cache string) develop.Develop {
 }
 
 type NeoForgeMeta struct {
-	done chan struct{}
-	Api  meta.NeoForgeApiMeta
+	done         chan struct{}
+	Api          meta.NeoForgeApiMeta
+	LegacyApi    meta.NeoForgeLegacyApiMeta
+	NeoGradle    meta.NeoGradleMeta
+	ModDevGradle meta.ModDevGradleMeta
 }
 
 func (f *NeoForge) Platform() develop.DevPlatform {
@@ -47,12 +59,29 @@ func (f *NeoForge) Platform() develop.DevPlatform {
 func (f *NeoForge) FetchCalls() []develop.DevFetch {
 	return []develop.DevFetch{
 		{"API", f.FetchApi},
+		{"Legacy API", f.FetchLegacyApi},
+		{"NeoGradle", f.FetchNeoGradle},
+		{"ModDevGradle", f.FetchModDevGradle},
 	}
 }
 
 func (f *NeoForge) ValidTree(tree fs.FS) bool {
-	_, ok := genericCheckOnePathExists(tree, neoForgeLoaderMetaPaths...)
-	return ok
+	if _, ok := genericCheckOnePathExists(tree, neoForgeLoaderMetaPaths...); ok {
+		return true
+	}
+	_, ok := genericCheckOnePathExists(tree, forgeLoaderMetaPaths...)
+	return ok && isNeoForgeTree(tree)
+}
+
+func isNeoForgeTree(tree fs.FS) bool {
+	return genericBuildMatches(tree, func(s string) bool {
+		for _, m := range neoForgeBuildRe.FindAllStringSubmatch(s, -1) {
+			if m[1] == "" {
+				return true
+			}
+		}
+		return false
+	})
 }
 
 func (f *NeoForge) ReadVersionFile(tree fs.FS, name string) (map[develop.PropVersion]string, error) {
@@ -77,6 +106,9 @@ func (f *NeoForge) ReadVersionFile(tree fs.FS, name string) (map[develop.PropVer
 	mapProp(a, develop.ModVersion, propM)
 	mapProp(a, develop.MinecraftVersion, propM)
 	mapProp(a, develop.NeoForgeVersion, propM)
+	mapProp(a, develop.NeoGradleVersion, propM)
+	mapProp(a, develop.ModDevGradleVersion, propM)
+	mapPluginVersions(a, tree)
 	return a, nil
 }
 
@@ -85,12 +117,30 @@ func (f *NeoForge) LatestVersion(prop develop.PropVersion, mcVersion string) (st
 	case develop.NeoForgeVersion:
 		a, err := f.LatestLoaderVersion(mcVersion)
 		return a, err == nil
+	case develop.NeoGradleVersion:
+		return shared.LatestChannelMavenVersion(shared.MavenMeta(f.Meta.NeoGradle), f.Conf.NeoGradleChannel)
+	case develop.ModDevGradleVersion:
+		return shared.LatestChannelMavenVersion(shared.MavenMeta(f.Meta.ModDevGradle), f.Conf.ModDevGradleChannel)
 	default:
 	}
 	return "", false
 }
 
+// LatestLoaderVersion uses the net.neoforged:forge artifact for Minecraft
+// 1.20.1, these versions contain the Minecraft version like Forge versions
 func (f *NeoForge) LatestLoaderVersion(mcVersion string) (string, error) {
+	if isNeoForgeLegacy(mcVersion) {
+		err := f.FetchLegacyApi()
+		if err != nil {
+			return "", err
+		}
+		version, ok := shared.LatestPrefixMavenVersion(shared.MavenMeta(f.Meta.LegacyApi), mcVersion+"-")
+		if !ok {
+			return "", fmt.Errorf("no forge loaders found")
+		}
+		return version, nil
+	}
+
 	err := f.FetchApi()
 	if err != nil {
 		return "", err
@@ -102,6 +152,11 @@ func (f *NeoForge) LatestLoaderVersion(mcVersion string) (string, error) {
 	return version, nil
 }
 
+func isNeoForgeLegacy(mcVersion string) bool {
+	v, err := semver.NewVersion(mcVersion)
+	return err == nil && !v.GreaterThan(neoForgeLegacyMinecraft)
+}
+
 func (f *NeoForge) FetchApi() (err error) {
 	f.Meta.Api, err = genericPlatformFetch[meta.NeoForgeApiMeta](f.Conf.Api, path.Join(f.Cache, "api.xml"), func(r io.Reader, m *meta.NeoForgeApiMeta) error {
 		return xml.NewDecoder(r).Decode(m)
@@ -110,3 +165,30 @@ func (f *NeoForge) FetchApi() (err error) {
 	})
 	return err
 }
+
+func (f *NeoForge) FetchLegacyApi() (err error) {
+	f.Meta.LegacyApi, err = genericPlatformFetch[meta.NeoForgeLegacyApiMeta](f.Conf.LegacyApi, path.Join(f.Cache, "legacy-api.xml"), func(r io.Reader, m *meta.NeoForgeLegacyApiMeta) error {
+		return xml.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.NeoForgeLegacyApiMeta) error {
+		return xml.NewEncoder(w).Encode(m)
+	})
+	return err
+}
+
+func (f *NeoForge) FetchNeoGradle() (err error) {
+	f.Meta.NeoGradle, err = genericPlatformFetch[meta.NeoGradleMeta](f.Conf.NeoGradle, path.Join(f.Cache, "neogradle.xml"), func(r io.Reader, m *meta.NeoGradleMeta) error {
+		return xml.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.NeoGradleMeta) error {
+		return xml.NewEncoder(w).Encode(m)
+	})
+	return err
+}
+
+func (f *NeoForge) FetchModDevGradle() (err error) {
+	f.Meta.ModDevGradle, err = genericPlatformFetch[meta.ModDevGradleMeta](f.Conf.ModDevGradle, path.Join(f.Cache, "moddevgradle.xml"), func(r io.Reader, m *meta.ModDevGradleMeta) error {
+		return xml.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.ModDevGradleMeta) error {
+		return xml.NewEncoder(w).Encode(m)
+	})
+	return err
+}
@@ -0,0 +1,172 @@
+package dev
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"github.com/magiconair/properties"
+	"github.com/mrmelon54/mcmodupdater/config"
+	"github.com/mrmelon54/mcmodupdater/develop"
+	"github.com/mrmelon54/mcmodupdater/meta"
+	"github.com/mrmelon54/mcmodupdater/meta/shared"
+	"github.com/mrmelon54/mcmodupdater/utils"
+	"io"
+	"io/fs"
+	"strconv"
+)
+
+var (
+	PlatformOrnithe = develop.DevPlatform{Name: "Ornithe"}
+	// words in the build files of Ornithe projects, these use the Fabric or
+	// Quilt mod metadata files with the Ploceus gradle plugin
+	ornitheBuildWords = []string{"ornithe", "ploceus", "feather_build"}
+)
+
+type Ornithe struct {
+	Conf  config.OrnitheDevelopConfig
+	Meta  *OrnitheMeta
+	Cache string
+}
+
+type OrnitheMeta struct {
+	done    chan struct{}
+	Game    meta.OrnitheGameMeta
+	Feather meta.OrnitheFeatherMeta
+	Loader  meta.OrnitheLoaderMeta
+	Osl     meta.OrnitheOslMeta
+}
+
+func ForOrnithe(conf config.DevelopConfig, cache string) develop.Develop {
+	return &Ornithe{
+		Conf:  conf.Ornithe,
+		Meta:  &OrnitheMeta{},
+		Cache: utils.PathJoin(cache, "ornithe"),
+	}
+}
+
+func (o *Ornithe) Platform() develop.DevPlatform {
+	return PlatformOrnithe
+}
+
+func (o *Ornithe) FetchCalls() []develop.DevFetch {
+	return []develop.DevFetch{
+		{"Game", o.FetchGame},
+		{"Feather", o.FetchFeather},
+		{"Loader", o.FetchLoader},
+		{"OSL", o.FetchOsl},
+	}
+}
+
+func (o *Ornithe) ValidTree(tree fs.FS) bool {
+	_, ok := genericCheckOnePathExists(tree, append(fabricLoaderMetaPaths, quiltLoaderMetaPaths...)...)
+	return ok && isOrnitheTree(tree)
+}
+
+func isOrnitheTree(tree fs.FS) bool {
+	return genericBuildContains(tree, ornitheBuildWords...)
+}
+
+func (o *Ornithe) ReadVersionFile(tree fs.FS, name string) (map[develop.PropVersion]string, error) {
+	if name == "" {
+		name = "gradle.properties"
+	}
+	gradlePropFile, err := tree.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("open gradle.properties: %w", err)
+	}
+	gradlePropContent, err := io.ReadAll(gradlePropFile)
+	if err != nil {
+		return nil, fmt.Errorf("read gradle.properties: %w", err)
+	}
+	prop, err := properties.Load(gradlePropContent, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	propM := prop.Map()
+	a := make(map[develop.PropVersion]string)
+	mapProp(a, develop.ModVersion, propM)
+	mapProp(a, develop.MinecraftVersion, propM)
+	mapProp(a, develop.FeatherBuildVersion, propM)
+	mapProp(a, develop.FabricLoaderVersion, propM)
+	mapProp(a, develop.OslVersion, propM)
+	mapPluginVersions(a, tree)
+	return a, nil
+}
+
+func (o *Ornithe) LatestVersion(prop develop.PropVersion, mcVersion string) (string, bool) {
+	// Ornithe only supports the versions listed in the game meta
+	if !o.isGameVersion(mcVersion) {
+		return "", false
+	}
+	switch prop {
+	case develop.FabricLoaderVersion:
+		a, err := o.LatestLoaderVersion(mcVersion)
+		return a, err == nil
+	case develop.FeatherBuildVersion:
+		// the properties file only contains the build number
+		if a, ok := shared.LatestYarnVersion(o.Meta.Feather, mcVersion); ok {
+			return strconv.Itoa(a.Build), true
+		}
+	case develop.OslVersion:
+		return shared.LatestChannelMavenVersion(shared.MavenMeta(o.Meta.Osl), shared.ChannelStable)
+	default:
+	}
+	return "", false
+}
+
+func (o *Ornithe) isGameVersion(mcVersion string) bool {
+	for _, i := range o.Meta.Game {
+		if i.Version == mcVersion {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *Ornithe) LatestLoaderVersion(_ string) (string, error) {
+	err := o.FetchLoader()
+	if err != nil {
+		return "", err
+	}
+	if len(o.Meta.Loader) < 1 {
+		return "", fmt.Errorf("no ornithe loaders found")
+	}
+	return o.Meta.Loader[0].Version, nil
+}
+
+func (o *Ornithe) FetchGame() (err error) {
+	o.Meta.Game, err = genericPlatformFetch[meta.OrnitheGameMeta](o.Conf.Game, utils.PathJoin(o.Cache, "game.json"), func(r io.Reader, m *meta.OrnitheGameMeta) error {
+		return json.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.OrnitheGameMeta) error {
+		return json.NewEncoder(w).Encode(m)
+	})
+	return err
+}
+
+func (o *Ornithe) FetchFeather() (err error) {
+	o.Meta.Feather, err = genericPlatformFetch[meta.OrnitheFeatherMeta](o.Conf.Feather, utils.PathJoin(o.Cache, "feather.json"), func(r io.Reader, m *meta.OrnitheFeatherMeta) error {
+		return json.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.OrnitheFeatherMeta) error {
+		return json.NewEncoder(w).Encode(m)
+	})
+	return err
+}
+
+func (o *Ornithe) FetchLoader() (err error) {
+	o.Meta.Loader, err = genericPlatformFetch[meta.OrnitheLoaderMeta](o.Conf.Loader, utils.PathJoin(o.Cache, "loader.json"), func(r io.Reader, m *meta.OrnitheLoaderMeta) error {
+		return json.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.OrnitheLoaderMeta) error {
+		return json.NewEncoder(w).Encode(m)
+	})
+	return err
+}
+
+func (o *Ornithe) FetchOsl() (err error) {
+	o.Meta.Osl, err = genericPlatformFetch[meta.OrnitheOslMeta](o.Conf.Osl, utils.PathJoin(o.Cache, "osl.xml"), func(r io.Reader, m *meta.OrnitheOslMeta) error {
+		return xml.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.OrnitheOslMeta) error {
+		return xml.NewEncoder(w).Encode(m)
+	})
+	return err
+}
@@ -0,0 +1,66 @@
+package dev
+
+import (
+	"github.com/Masterminds/semver/v3"
+)
+
+// packFormats is the bundled table of resource and data pack formats, each
+// entry applies from its Minecraft version until the next entry. Refresh it
+// from the pack_version field of the version JSON after each release.
+var packFormats = []struct {
+	Minecraft      string
+	Resource, Data int
+}{
+	{"1.6.1", 1, 0},
+	{"1.9", 2, 0},
+	{"1.11", 3, 0},
+	{"1.13", 4, 4},
+	{"1.15", 5, 5},
+	{"1.16.2", 6, 6},
+	{"1.17", 7, 7},
+	{"1.18", 8, 8},
+	{"1.18.2", 8, 9},
+	{"1.19", 9, 10},
+	{"1.19.3", 12, 10},
+	{"1.19.4", 13, 12},
+	{"1.20", 15, 15},
+	{"1.20.2", 18, 18},
+	{"1.20.3", 22, 26},
+	{"1.20.5", 32, 41},
+	{"1.21", 34, 48},
+	{"1.21.2", 42, 57},
+	{"1.21.4", 46, 61},
+	{"1.21.5", 55, 71},
+	{"1.21.6", 63, 80},
+	{"1.21.7", 64, 81},
+	{"1.21.8", 64, 81},
+}
+
+// BundledPackFormat looks up the pack formats for a release version, versions
+// newer than the last entry aren't known
+func BundledPackFormat(mcVersion string) (resource, data int, ok bool) {
+	v, err := semver.NewVersion(mcVersion)
+	if err != nil || v.Prerelease() != "" {
+		return 0, 0, false
+	}
+	newest := semver.MustParse(packFormats[len(packFormats)-1].Minecraft)
+	if v.GreaterThan(newest) {
+		return 0, 0, false
+	}
+	for _, i := range packFormats {
+		if v.LessThan(semver.MustParse(i.Minecraft)) {
+			break
+		}
+		resource, data, ok = i.Resource, i.Data, true
+	}
+	return
+}
+
+// PackFormat returns the resource and data pack formats for the Minecraft
+// version from the version JSON, falling back to the bundled table
+func (m *Mojang) PackFormat(mcVersion string) (resource, data int, ok bool) {
+	if v, err := m.Version(mcVersion); err == nil && v.PackVersion != nil {
+		return v.PackVersion.Resource, v.PackVersion.Data, true
+	}
+	return BundledPackFormat(mcVersion)
+}
@@ -0,0 +1,110 @@
+package dev
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/mrmelon54/mcmodupdater/config"
+	"github.com/mrmelon54/mcmodupdater/develop"
+	"github.com/mrmelon54/mcmodupdater/meta"
+	"github.com/mrmelon54/mcmodupdater/meta/shared"
+	"github.com/mrmelon54/mcmodupdater/utils"
+	"io"
+	"io/fs"
+	"strings"
+)
+
+var (
+	PlatformPaper        = develop.DevPlatform{Name: "Paper"}
+	paperLoaderMetaPaths = []string{
+		"src/main/resources/plugin.yml",
+		"src/main/resources/paper-plugin.yml",
+		"resources/plugin.yml",
+		"resources/paper-plugin.yml",
+	}
+)
+
+// Paper is used for Paper, Spigot and Bukkit plugins, the Paper API version is
+// read from the properties file or the dependency in the build scripts
+type Paper struct {
+	Conf  config.PaperDevelopConfig
+	Meta  *PaperMeta
+	Cache string
+}
+
+func ForPaper(conf config.DevelopConfig, cache string) develop.Develop {
+	return &Paper{
+		Conf:  conf.Paper,
+		Meta:  &PaperMeta{},
+		Cache: utils.PathJoin(cache, "paper"),
+	}
+}
+
+type PaperMeta struct {
+	done chan struct{}
+	Api  meta.PaperApiMeta
+}
+
+func (p *Paper) Platform() develop.DevPlatform {
+	return PlatformPaper
+}
+
+func (p *Paper) FetchCalls() []develop.DevFetch {
+	return []develop.DevFetch{
+		{"API", p.FetchApi},
+	}
+}
+
+func (p *Paper) ValidTree(tree fs.FS) bool {
+	_, ok := genericCheckOnePathExists(tree, paperLoaderMetaPaths...)
+	return ok
+}
+
+func (p *Paper) ReadVersionFile(tree fs.FS, name string) (map[develop.PropVersion]string, error) {
+	propM, err := genericReadOptionalProps(tree, name)
+	if err != nil {
+		return nil, err
+	}
+
+	a := make(map[develop.PropVersion]string)
+	mapProp(a, develop.ModVersion, propM)
+	mapProp(a, develop.MinecraftVersion, propM)
+	mapProp(a, develop.PaperApiVersion, propM)
+	mapPluginVersions(a, tree)
+	if _, ok := a[develop.MinecraftVersion]; !ok {
+		if mc, _, ok := strings.Cut(a[develop.PaperApiVersion], "-"); ok {
+			a[develop.MinecraftVersion] = mc
+		}
+	}
+	return a, nil
+}
+
+func (p *Paper) LatestVersion(prop develop.PropVersion, mcVersion string) (string, bool) {
+	switch prop {
+	case develop.PaperApiVersion:
+		a, err := p.LatestLoaderVersion(mcVersion)
+		return a, err == nil
+	default:
+	}
+	return "", false
+}
+
+func (p *Paper) LatestLoaderVersion(mcVersion string) (string, error) {
+	err := p.FetchApi()
+	if err != nil {
+		return "", err
+	}
+	version, ok := shared.LatestPrefixMavenVersion(shared.MavenMeta(p.Meta.Api), mcVersion+"-")
+	if !ok {
+		return "", fmt.Errorf("no paper api versions found")
+	}
+	return version, nil
+}
+
+func (p *Paper) FetchApi() (err error) {
+	p.Meta.Api, err = genericPlatformFetch[meta.PaperApiMeta](p.Conf.Api, utils.PathJoin(p.Cache, "api.xml"), func(r io.Reader, m *meta.PaperApiMeta) error {
+		return xml.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.PaperApiMeta) error {
+		return xml.NewEncoder(w).Encode(m)
+	})
+	return err
+}
@@ -3,6 +3,7 @@ package dev
 import (
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"github.com/komkom/toml"
 	"github.com/magiconair/properties"
@@ -65,7 +66,7 @@ func (q *Quilt) FetchCalls() []develop.DevFetch {
 
 func (q *Quilt) ValidTree(tree fs.FS) bool {
 	_, ok := genericCheckOnePathExists(tree, quiltLoaderMetaPaths...)
-	return ok
+	return ok && !isOrnitheTree(tree)
 }
 
 func (q *Quilt) ReadVersionFile(tree fs.FS, name string) (map[develop.PropVersion]string, error) {
@@ -85,18 +86,25 @@ func (q *Quilt) ReadVersionFile(tree fs.FS, name string) (map[develop.PropVersio
 		return nil, err
 	}
 
+	propMV := prop.Map()
+
+	// version folders of multi-version projects share the root catalog, so
+	// without a catalog the versions are read from the properties file
+	propM := propMV
 	gradleLibVersions, err := tree.Open("gradle/libs.versions.toml")
-	if err != nil {
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
 		return nil, fmt.Errorf("contents gradle/libs.versions.toml: %w", err)
-	}
-	var v libVersion.LibVersion
-	err = json.NewDecoder(toml.New(gradleLibVersions)).Decode(&v)
-	if err != nil {
-		return nil, err
+	default:
+		var v libVersion.LibVersion
+		err = json.NewDecoder(toml.New(gradleLibVersions)).Decode(&v)
+		if err != nil {
+			return nil, err
+		}
+		propM = v.Versions
 	}
 
-	propM := v.Versions
-	propMV := prop.Map()
 	a := make(map[develop.PropVersion]string)
 	mapProp(a, develop.ModVersion, propMV)
 	mapProp(a, develop.MinecraftVersion, propM)
@@ -0,0 +1,171 @@
+package dev
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/Masterminds/semver/v3"
+	"github.com/mrmelon54/mcmodupdater/config"
+	"github.com/mrmelon54/mcmodupdater/develop"
+	"github.com/mrmelon54/mcmodupdater/edit"
+	"github.com/mrmelon54/mcmodupdater/meta"
+	"github.com/mrmelon54/mcmodupdater/meta/shared"
+	"github.com/mrmelon54/mcmodupdater/utils"
+	"io"
+	"io/fs"
+)
+
+var (
+	PlatformSponge        = develop.DevPlatform{Name: "Sponge"}
+	spongeLoaderMetaPaths = []string{
+		"src/main/resources/META-INF/sponge_plugins.json",
+		"resources/META-INF/sponge_plugins.json",
+	}
+	// sponge_plugins.json is usually generated by the SpongeGradle plugin
+	spongeBuildWords = []string{"org.spongepowered.gradle.plugin"}
+
+	// spongeApiMinecraft maps each SpongeAPI major version to the Minecraft
+	// version it targets
+	spongeApiMinecraft = map[uint64]string{
+		7:  "1.12.2",
+		8:  "1.16.5",
+		9:  "1.18.2",
+		10: "1.19.4",
+		11: "1.20.6",
+		12: "1.21.1",
+		13: "1.21.3",
+		14: "1.21.4",
+	}
+)
+
+type Sponge struct {
+	Conf  config.SpongeDevelopConfig
+	Meta  *SpongeMeta
+	Cache string
+}
+
+func ForSponge(conf config.DevelopConfig, cache string) develop.Develop {
+	return &Sponge{
+		Conf:  conf.Sponge,
+		Meta:  &SpongeMeta{},
+		Cache: utils.PathJoin(cache, "sponge"),
+	}
+}
+
+type SpongeMeta struct {
+	done chan struct{}
+	Api  meta.SpongeApiMeta
+}
+
+func (s *Sponge) Platform() develop.DevPlatform {
+	return PlatformSponge
+}
+
+func (s *Sponge) FetchCalls() []develop.DevFetch {
+	return []develop.DevFetch{
+		{"API", s.FetchApi},
+	}
+}
+
+func (s *Sponge) ValidTree(tree fs.FS) bool {
+	if _, ok := genericCheckOnePathExists(tree, spongeLoaderMetaPaths...); ok {
+		return true
+	}
+	return genericBuildContains(tree, spongeBuildWords...)
+}
+
+func (s *Sponge) ReadVersionFile(tree fs.FS, name string) (map[develop.PropVersion]string, error) {
+	propM, err := genericReadOptionalProps(tree, name)
+	if err != nil {
+		return nil, err
+	}
+
+	a := make(map[develop.PropVersion]string)
+	mapProp(a, develop.ModVersion, propM)
+	mapProp(a, develop.MinecraftVersion, propM)
+	mapProp(a, develop.SpongeApiVersion, propM)
+	mapPluginVersions(a, tree)
+	if _, ok := a[develop.SpongeApiVersion]; !ok {
+		if v := edit.ReadFirst(tree, edit.GradleBuildPaths, edit.ReadSpongeApiVersion); v != "" {
+			a[develop.SpongeApiVersion] = v
+		}
+	}
+	if _, ok := a[develop.MinecraftVersion]; !ok {
+		if mc, ok := SpongeApiMinecraft(a[develop.SpongeApiVersion]); ok {
+			a[develop.MinecraftVersion] = mc
+		}
+	}
+	return a, nil
+}
+
+func (s *Sponge) LatestVersion(prop develop.PropVersion, mcVersion string) (string, bool) {
+	switch prop {
+	case develop.SpongeApiVersion:
+		a, err := s.LatestLoaderVersion(mcVersion)
+		return a, err == nil
+	default:
+	}
+	return "", false
+}
+
+// LatestLoaderVersion returns the latest SpongeAPI release for the API
+// version targeting the Minecraft version
+func (s *Sponge) LatestLoaderVersion(mcVersion string) (string, error) {
+	major, ok := SpongeApiMajor(mcVersion)
+	if !ok {
+		return "", fmt.Errorf("no spongeapi version targets minecraft %s", mcVersion)
+	}
+	err := s.FetchApi()
+	if err != nil {
+		return "", err
+	}
+	channels := []string{s.Conf.ApiChannel}
+	if s.Conf.ApiChannel != shared.ChannelSnapshot {
+		channels = append(channels, shared.ChannelSnapshot)
+	}
+	for _, channel := range channels {
+		var latest string
+		var latestVer *semver.Version
+		for _, i := range s.Meta.Api.Versioning.Versions.Version {
+			v, err := semver.NewVersion(i)
+			if err != nil || v.Major() != major || !shared.InChannel(v, channel) {
+				continue
+			}
+			if latestVer == nil || v.GreaterThan(latestVer) {
+				latest, latestVer = i, v
+			}
+		}
+		if latest != "" {
+			return latest, nil
+		}
+	}
+	return "", fmt.Errorf("no spongeapi versions found")
+}
+
+// SpongeApiMajor finds the SpongeAPI major version for the Minecraft version
+func SpongeApiMajor(mcVersion string) (uint64, bool) {
+	for k, v := range spongeApiMinecraft {
+		if v == mcVersion {
+			return k, true
+		}
+	}
+	return 0, false
+}
+
+// SpongeApiMinecraft finds the Minecraft version targeted by a SpongeAPI version
+func SpongeApiMinecraft(apiVersion string) (string, bool) {
+	v, err := semver.NewVersion(apiVersion)
+	if err != nil {
+		return "", false
+	}
+	a, ok := spongeApiMinecraft[v.Major()]
+	return a, ok
+}
+
+func (s *Sponge) FetchApi() (err error) {
+	s.Meta.Api, err = genericPlatformFetch[meta.SpongeApiMeta](s.Conf.Api, utils.PathJoin(s.Cache, "api.xml"), func(r io.Reader, m *meta.SpongeApiMeta) error {
+		return xml.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.SpongeApiMeta) error {
+		return xml.NewEncoder(w).Encode(m)
+	})
+	return err
+}
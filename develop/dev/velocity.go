@@ -0,0 +1,106 @@
+package dev
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/mrmelon54/mcmodupdater/config"
+	"github.com/mrmelon54/mcmodupdater/develop"
+	"github.com/mrmelon54/mcmodupdater/meta"
+	"github.com/mrmelon54/mcmodupdater/meta/shared"
+	"github.com/mrmelon54/mcmodupdater/utils"
+	"io"
+	"io/fs"
+)
+
+var (
+	PlatformVelocity        = develop.DevPlatform{Name: "Velocity"}
+	velocityLoaderMetaPaths = []string{
+		"src/main/resources/velocity-plugin.json",
+		"resources/velocity-plugin.json",
+	}
+	// velocity-plugin.json is usually generated from the @Plugin annotation
+	velocityPluginImport = "com.velocitypowered.api.plugin.Plugin"
+)
+
+type Velocity struct {
+	Conf  config.VelocityDevelopConfig
+	Meta  *VelocityMeta
+	Cache string
+}
+
+func ForVelocity(conf config.DevelopConfig, cache string) develop.Develop {
+	return &Velocity{
+		Conf:  conf.Velocity,
+		Meta:  &VelocityMeta{},
+		Cache: utils.PathJoin(cache, "velocity"),
+	}
+}
+
+type VelocityMeta struct {
+	done chan struct{}
+	Api  meta.VelocityApiMeta
+}
+
+func (v *Velocity) Platform() develop.DevPlatform {
+	return PlatformVelocity
+}
+
+func (v *Velocity) FetchCalls() []develop.DevFetch {
+	return []develop.DevFetch{
+		{"API", v.FetchApi},
+	}
+}
+
+func (v *Velocity) ValidTree(tree fs.FS) bool {
+	if _, ok := genericCheckOnePathExists(tree, velocityLoaderMetaPaths...); ok {
+		return true
+	}
+	return genericSourceImports(tree, velocityPluginImport)
+}
+
+func (v *Velocity) ReadVersionFile(tree fs.FS, name string) (map[develop.PropVersion]string, error) {
+	propM, err := genericReadOptionalProps(tree, name)
+	if err != nil {
+		return nil, err
+	}
+
+	a := make(map[develop.PropVersion]string)
+	mapProp(a, develop.ModVersion, propM)
+	mapProp(a, develop.VelocityApiVersion, propM)
+	mapPluginVersions(a, tree)
+	return a, nil
+}
+
+// LatestVersion ignores the Minecraft version, proxies support many versions
+// with the same API
+func (v *Velocity) LatestVersion(prop develop.PropVersion, _ string) (string, bool) {
+	switch prop {
+	case develop.VelocityApiVersion:
+		a, err := v.LatestLoaderVersion("")
+		return a, err == nil
+	default:
+	}
+	return "", false
+}
+
+// LatestLoaderVersion returns the latest API version
+func (v *Velocity) LatestLoaderVersion(_ string) (string, error) {
+	err := v.FetchApi()
+	if err != nil {
+		return "", err
+	}
+	version, ok := shared.LatestChannelMavenVersion(shared.MavenMeta(v.Meta.Api), v.Conf.ApiChannel)
+	if !ok {
+		return "", fmt.Errorf("no velocity api versions found")
+	}
+	return version, nil
+}
+
+func (v *Velocity) FetchApi() (err error) {
+	v.Meta.Api, err = genericPlatformFetch[meta.VelocityApiMeta](v.Conf.Api, utils.PathJoin(v.Cache, "api.xml"), func(r io.Reader, m *meta.VelocityApiMeta) error {
+		return xml.NewDecoder(r).Decode(m)
+	}, func(w io.Writer, m meta.VelocityApiMeta) error {
+		return xml.NewEncoder(w).Encode(m)
+	})
+	return err
+}
@@ -26,4 +26,12 @@ type DevFetch struct {
 type PlatformVersions struct {
 	Platform Develop
 	Versions map[PropVersion]string
+
+	// SubPlatforms are the platforms of the sub-projects, e.g. the loaders of
+	// an Architectury project
+	SubPlatforms []DevPlatform
+
+	// ForgePromotion is the forge_promotion option of the project, empty uses
+	// the configured promotion
+	ForgePromotion string
 }
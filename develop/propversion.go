@@ -12,41 +12,108 @@ func PropVersionFromKey(k string) (PropVersion, bool) {
 	return a, ok
 }
 
+func (v PropVersion) PluginIds() []string { return propVersionPluginIds[v] }
+
+func PropVersionFromPluginId(id string) (PropVersion, bool) {
+	a, ok := propVersionFromPluginIds[id]
+	return a, ok
+}
+
+func (v PropVersion) Modules() []string { return propVersionModules[v] }
+
+// PropVersionFromModule finds the property for a "group:artifact" module
+func PropVersionFromModule(module string) (PropVersion, bool) {
+	a, ok := propVersionFromModules[module]
+	return a, ok
+}
+
 //go:generate stringer -type=PropVersion -linecomment
 
 const (
-	_                     = PropVersion(iota)
-	ModVersion            // Version
-	MinecraftVersion      // Minecraft
-	ArchitecturyVersion   // Architectury
-	FabricLoaderVersion   // Fabric Loader
-	FabricApiVersion      // Fabric API
-	YarnMappingsVersion   // Yarn Mappings
-	ForgeVersion          // Forge
-	ForgeMappingsVersion  // Forge Mappings
-	QuiltLoaderVersion    // Quilt Loader
-	QuiltFabricApiVersion // Quilted Fabric API
-	QuiltMappingsVersion  // Quilt Mappings
-	NeoForgeVersion       // NeoForge
+	_                       = PropVersion(iota)
+	ModVersion              // Version
+	MinecraftVersion        // Minecraft
+	ArchitecturyVersion     // Architectury
+	FabricLoaderVersion     // Fabric Loader
+	FabricApiVersion        // Fabric API
+	YarnMappingsVersion     // Yarn Mappings
+	ForgeVersion            // Forge
+	ForgeMappingsVersion    // Forge Mappings
+	QuiltLoaderVersion      // Quilt Loader
+	QuiltFabricApiVersion   // Quilted Fabric API
+	QuiltMappingsVersion    // Quilt Mappings
+	NeoForgeVersion         // NeoForge
+	LoomVersion             // Fabric Loom
+	ArchitecturyLoomVersion // Architectury Loom
+	ForgeGradleVersion      // ForgeGradle
+	NeoGradleVersion        // NeoGradle
+	ModDevGradleVersion     // ModDevGradle
+	GradleVersion           // Gradle
+	JavaVersion             // Java
+	LiteLoaderVersion       // LiteLoader
+	LegacyFabricApiVersion  // Legacy Fabric API
+	FeatherBuildVersion     // Feather Mappings
+	OslVersion              // OSL
+	PaperApiVersion         // Paper API
+	VelocityApiVersion      // Velocity API
+	BungeeCordApiVersion    // BungeeCord API
+	WaterfallApiVersion     // Waterfall API
+	SpongeApiVersion        // SpongeAPI
 )
 
 var (
 	propVersionKeyMap = map[PropVersion]string{
-		ModVersion:            "mod_version",
-		MinecraftVersion:      "minecraft_version",
-		ArchitecturyVersion:   "architectury_version",
-		FabricLoaderVersion:   "fabric_loader_version",
-		FabricApiVersion:      "fabric_api_version",
-		YarnMappingsVersion:   "yarn_mappings",
-		ForgeVersion:          "forge_version",
-		ForgeMappingsVersion:  "forge_mappings_version",
-		QuiltLoaderVersion:    "quilt_loader_version",
-		QuiltFabricApiVersion: "quilt_fabric_api_version",
-		QuiltMappingsVersion:  "quilt_mappings",
-		NeoForgeVersion:       "neoforge_version",
+		ModVersion:              "mod_version",
+		MinecraftVersion:        "minecraft_version",
+		ArchitecturyVersion:     "architectury_version",
+		FabricLoaderVersion:     "fabric_loader_version",
+		FabricApiVersion:        "fabric_api_version",
+		YarnMappingsVersion:     "yarn_mappings",
+		ForgeVersion:            "forge_version",
+		ForgeMappingsVersion:    "forge_mappings_version",
+		QuiltLoaderVersion:      "quilt_loader_version",
+		QuiltFabricApiVersion:   "quilt_fabric_api_version",
+		QuiltMappingsVersion:    "quilt_mappings",
+		NeoForgeVersion:         "neoforge_version",
+		LoomVersion:             "loom_version",
+		ArchitecturyLoomVersion: "architectury_loom_version",
+		ForgeGradleVersion:      "forge_gradle_version",
+		NeoGradleVersion:        "neogradle_version",
+		ModDevGradleVersion:     "moddevgradle_version",
+		GradleVersion:           "gradle_version",
+		JavaVersion:             "java_version",
+		LiteLoaderVersion:       "liteloader_version",
+		LegacyFabricApiVersion:  "legacy_fabric_api_version",
+		FeatherBuildVersion:     "feather_build",
+		OslVersion:              "osl_version",
+		PaperApiVersion:         "paper_api_version",
+		VelocityApiVersion:      "velocity_api_version",
+		BungeeCordApiVersion:    "bungeecord_api_version",
+		WaterfallApiVersion:     "waterfall_api_version",
+		SpongeApiVersion:        "spongeapi_version",
+	}
+	// gradle plugin ids for properties which are also plugin versions
+	propVersionPluginIds = map[PropVersion][]string{
+		LoomVersion:             {"fabric-loom", "net.fabricmc.fabric-loom"},
+		ArchitecturyLoomVersion: {"dev.architectury.loom"},
+		ForgeGradleVersion:      {"net.minecraftforge.gradle"},
+		NeoGradleVersion:        {"net.neoforged.gradle.userdev"},
+		ModDevGradleVersion:     {"net.neoforged.moddev", "net.neoforged.moddev.legacyforge"},
+	}
+	// maven modules for properties which are also dependency versions
+	propVersionModules = map[PropVersion][]string{
+		PaperApiVersion:      {"io.papermc.paper:paper-api"},
+		VelocityApiVersion:   {"com.velocitypowered:velocity-api"},
+		BungeeCordApiVersion: {"net.md-5:bungeecord-api"},
+		WaterfallApiVersion:  {"io.github.waterfallmc:waterfall-api"},
+		SpongeApiVersion:     {"org.spongepowered:spongeapi"},
 	}
 	// basically inverted propVersionKeyMap
 	propVersionFromKeys map[string]PropVersion
+	// basically inverted propVersionPluginIds
+	propVersionFromPluginIds map[string]PropVersion
+	// basically inverted propVersionModules
+	propVersionFromModules map[string]PropVersion
 )
 
 func init() {
@@ -54,4 +121,16 @@ func init() {
 	for k, v := range propVersionKeyMap {
 		propVersionFromKeys[v] = k
 	}
+	propVersionFromPluginIds = make(map[string]PropVersion)
+	for k, v := range propVersionPluginIds {
+		for _, i := range v {
+			propVersionFromPluginIds[i] = k
+		}
+	}
+	propVersionFromModules = make(map[string]PropVersion)
+	for k, v := range propVersionModules {
+		for _, i := range v {
+			propVersionFromModules[i] = k
+		}
+	}
 }
@@ -20,11 +20,27 @@ func _() {
 	_ = x[QuiltFabricApiVersion-10]
 	_ = x[QuiltMappingsVersion-11]
 	_ = x[NeoForgeVersion-12]
+	_ = x[LoomVersion-13]
+	_ = x[ArchitecturyLoomVersion-14]
+	_ = x[ForgeGradleVersion-15]
+	_ = x[NeoGradleVersion-16]
+	_ = x[ModDevGradleVersion-17]
+	_ = x[GradleVersion-18]
+	_ = x[JavaVersion-19]
+	_ = x[LiteLoaderVersion-20]
+	_ = x[LegacyFabricApiVersion-21]
+	_ = x[FeatherBuildVersion-22]
+	_ = x[OslVersion-23]
+	_ = x[PaperApiVersion-24]
+	_ = x[VelocityApiVersion-25]
+	_ = x[BungeeCordApiVersion-26]
+	_ = x[WaterfallApiVersion-27]
+	_ = x[SpongeApiVersion-28]
 }
 
-const _PropVersion_name = "VersionMinecraftArchitecturyFabric LoaderFabric APIYarn MappingsForgeForge MappingsQuilt LoaderQuilted Fabric APIQuilt MappingsNeoForgeVersion"
+const _PropVersion_name = "VersionMinecraftArchitecturyFabric LoaderFabric APIYarn MappingsForgeForge MappingsQuilt LoaderQuilted Fabric APIQuilt MappingsNeoForgeFabric LoomArchitectury LoomForgeGradleNeoGradleModDevGradleGradleJavaLiteLoaderLegacy Fabric APIFeather MappingsOSLPaper APIVelocity APIBungeeCord APIWaterfall APISpongeAPI"
 
-var _PropVersion_index = [...]uint8{0, 7, 16, 28, 41, 51, 64, 69, 83, 95, 113, 127, 142}
+var _PropVersion_index = [...]uint16{0, 7, 16, 28, 41, 51, 64, 69, 83, 95, 113, 127, 135, 146, 163, 174, 183, 195, 201, 205, 215, 232, 248, 251, 260, 272, 286, 299, 308}
 
 func (i PropVersion) String() string {
 	i -= 1
@@ -0,0 +1,174 @@
+package edit
+
+import (
+	"bufio"
+	"github.com/mrmelon54/mcmodupdater/develop"
+	"io"
+	"regexp"
+	"strings"
+)
+
+const GradleVersionCatalogPath = "gradle/libs.versions.toml"
+
+var (
+	catalogSectionRe   = regexp.MustCompile(`^\s*\[([\w.\-]+)]`)
+	catalogVersionRe   = regexp.MustCompile(`^(\s*([\w.\-]+)\s*=\s*")([^"]*)(".*)$`)
+	catalogPluginIdRe  = regexp.MustCompile(`\bid\s*=\s*"([^"]+)"`)
+	catalogPluginVerRe = regexp.MustCompile(`(\bversion\s*=\s*")([^"]*)(")`)
+	catalogPluginRefRe = regexp.MustCompile(`\bversion(?:\.ref\s*=|\s*=\s*\{\s*ref\s*=)\s*"([^"]+)"`)
+	catalogPluginStrRe = regexp.MustCompile(`^(\s*[\w.\-]+\s*=\s*"([^":]+):)([^"]+)(".*)$`)
+	catalogModuleRe    = regexp.MustCompile(`\bmodule\s*=\s*"([^"]+)"`)
+	catalogGroupRe     = regexp.MustCompile(`\bgroup\s*=\s*"([^"]+)"`)
+	catalogNameRe      = regexp.MustCompile(`\bname\s*=\s*"([^"]+)"`)
+	catalogLibStrRe    = regexp.MustCompile(`^(\s*[\w.\-]+\s*=\s*"([^":]+:[^":]+):)([^"]+)(".*)$`)
+)
+
+// VersionCatalog updates the plugin versions in the [plugins] table and known
+// dependency versions in the [libraries] table, along with the [versions]
+// entries they reference, and [versions] entries named after a property key.
+// Comments and formatting are preserved.
+func VersionCatalog(out io.StringWriter, in io.Reader, ver map[develop.PropVersion]string) error {
+	var lines []string
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	// find the version references used by plugins and libraries
+	refs := make(map[string]develop.PropVersion)
+	section := ""
+	for _, t := range lines {
+		if m := catalogSectionRe.FindStringSubmatch(t); m != nil {
+			section = m[1]
+			continue
+		}
+		var p develop.PropVersion
+		var ok bool
+		switch section {
+		case "plugins":
+			if id := catalogPluginIdRe.FindStringSubmatch(t); id != nil {
+				p, ok = develop.PropVersionFromPluginId(id[1])
+			}
+		case "libraries":
+			p, ok = catalogLibraryProp(t)
+		}
+		if !ok {
+			continue
+		}
+		if ref := catalogPluginRefRe.FindStringSubmatch(t); ref != nil {
+			refs[ref[1]] = p
+		}
+	}
+
+	section = ""
+	for _, t := range lines {
+		if m := catalogSectionRe.FindStringSubmatch(t); m != nil {
+			section = m[1]
+		} else if !strings.HasPrefix(strings.TrimSpace(t), "#") {
+			switch section {
+			case "versions":
+				t = updateCatalogVersion(t, refs, ver)
+			case "plugins":
+				t = updateCatalogPlugin(t, ver)
+			case "libraries":
+				t = updateCatalogLibrary(t, ver)
+			}
+		}
+		if _, err := out.WriteString(t + "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func updateCatalogVersion(t string, refs map[string]develop.PropVersion, ver map[develop.PropVersion]string) string {
+	m := catalogVersionRe.FindStringSubmatch(t)
+	if m == nil {
+		return t
+	}
+	p, ok := refs[m[2]]
+	if !ok {
+		p, ok = develop.PropVersionFromKey(m[2])
+	}
+	if !ok {
+		return t
+	}
+	if v, ok := ver[p]; ok && !IsDynamicVersion(m[3]) {
+		return m[1] + v + m[4]
+	}
+	return t
+}
+
+func updateCatalogPlugin(t string, ver map[develop.PropVersion]string) string {
+	// string notation `loom = "fabric-loom:1.5-SNAPSHOT"`
+	if m := catalogPluginStrRe.FindStringSubmatch(t); m != nil {
+		if p, ok := develop.PropVersionFromPluginId(m[2]); ok && !IsDynamicVersion(m[3]) {
+			if v, ok := ver[p]; ok {
+				return m[1] + v + m[4]
+			}
+		}
+		return t
+	}
+
+	id := catalogPluginIdRe.FindStringSubmatch(t)
+	if id == nil {
+		return t
+	}
+	p, ok := develop.PropVersionFromPluginId(id[1])
+	if !ok {
+		return t
+	}
+	v, ok := ver[p]
+	if !ok {
+		return t
+	}
+	if m := catalogPluginVerRe.FindStringSubmatch(t); m != nil && IsDynamicVersion(m[2]) {
+		return t
+	}
+	return catalogPluginVerRe.ReplaceAllString(t, "${1}"+escapeReplacement(v)+"${3}")
+}
+
+// catalogLibraryProp finds the property for a library using the module or
+// group and name notation
+func catalogLibraryProp(t string) (develop.PropVersion, bool) {
+	if m := catalogModuleRe.FindStringSubmatch(t); m != nil {
+		return develop.PropVersionFromModule(m[1])
+	}
+	g, n := catalogGroupRe.FindStringSubmatch(t), catalogNameRe.FindStringSubmatch(t)
+	if g != nil && n != nil {
+		return develop.PropVersionFromModule(g[1] + ":" + n[1])
+	}
+	return 0, false
+}
+
+func updateCatalogLibrary(t string, ver map[develop.PropVersion]string) string {
+	// string notation `paper-api = "io.papermc.paper:paper-api:1.20.4-R0.1-SNAPSHOT"`
+	if m := catalogLibStrRe.FindStringSubmatch(t); m != nil {
+		if p, ok := develop.PropVersionFromModule(m[2]); ok && !IsDynamicVersion(m[3]) {
+			if v, ok := ver[p]; ok {
+				return m[1] + v + m[4]
+			}
+		}
+		return t
+	}
+
+	p, ok := catalogLibraryProp(t)
+	if !ok {
+		return t
+	}
+	v, ok := ver[p]
+	if !ok {
+		return t
+	}
+	if m := catalogPluginVerRe.FindStringSubmatch(t); m != nil && IsDynamicVersion(m[2]) {
+		return t
+	}
+	return catalogPluginVerRe.ReplaceAllString(t, "${1}"+escapeReplacement(v)+"${3}")
+}
+
+func escapeReplacement(s string) string {
+	return strings.ReplaceAll(s, "$", "$$")
+}
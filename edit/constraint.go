@@ -0,0 +1,49 @@
+package edit
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Strategies for writing dependency constraints in mod metadata
+const (
+	// ConstraintKeep keeps the operator already used by the constraint
+	ConstraintKeep  = "keep"
+	ConstraintExact = "exact"
+	ConstraintTilde = "tilde"
+	ConstraintGte   = "gte"
+)
+
+// a single constraint like "~1.20.1", ">=0.14.21" or "1.20.1"
+var singleConstraintRe = regexp.MustCompile(`^\s*(>=|<=|>|<|=|~|\^)?\s*([^\s<>=~^*]+)\s*$`)
+
+// IsPlaceholder reports whether the value is expanded at build time
+func IsPlaceholder(v string) bool {
+	return strings.Contains(v, "${") || strings.Contains(v, "$ {")
+}
+
+// FormatConstraint rewrites the constraint for the version using the strategy,
+// placeholders and constraints with multiple parts are not changed and
+// wildcards are only replaced when the strategy isn't ConstraintKeep
+func FormatConstraint(old, version, strategy string) (string, bool) {
+	if IsPlaceholder(old) {
+		return old, false
+	}
+	m := singleConstraintRe.FindStringSubmatch(old)
+	if strings.TrimSpace(old) == "*" && strategy != ConstraintKeep {
+		m = []string{old, "", ""}
+	}
+	if m == nil {
+		return old, false
+	}
+	switch strategy {
+	case ConstraintExact:
+		return version, true
+	case ConstraintTilde:
+		return "~" + version, true
+	case ConstraintGte:
+		return ">=" + version, true
+	default:
+		return m[1] + version, true
+	}
+}
@@ -0,0 +1,50 @@
+package edit
+
+import (
+	"bufio"
+	"github.com/mrmelon54/mcmodupdater/develop"
+	"io"
+	"regexp"
+)
+
+// matches `"io.papermc.paper:paper-api:1.20.4-R0.1-SNAPSHOT"` in a dependencies
+// block, versions using variables or string templates are skipped
+var dependencyLineRe = regexp.MustCompile(`(["']([\w.\-]+:[\w.\-]+):)([^"'$:@]+)([:@][^"']*)?(["'])`)
+
+// ReadGradleDependencies finds the versions of known dependencies
+func ReadGradleDependencies(in io.Reader) (map[develop.PropVersion]string, error) {
+	a := make(map[develop.PropVersion]string)
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		for _, m := range dependencyLineRe.FindAllStringSubmatch(scanner.Text(), -1) {
+			if p, ok := develop.PropVersionFromModule(m[2]); ok && !IsDynamicVersion(m[3]) {
+				a[p] = m[3]
+			}
+		}
+	}
+	return a, scanner.Err()
+}
+
+// GradleDependencies updates the versions of known dependencies
+func GradleDependencies(out io.StringWriter, in io.Reader, ver map[develop.PropVersion]string) (err error) {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() && err == nil {
+		t := dependencyLineRe.ReplaceAllStringFunc(scanner.Text(), func(s string) string {
+			m := dependencyLineRe.FindStringSubmatch(s)
+			if IsDynamicVersion(m[3]) {
+				return s
+			}
+			if p, ok := develop.PropVersionFromModule(m[2]); ok {
+				if v, ok := ver[p]; ok {
+					return m[1] + v + m[4] + m[5]
+				}
+			}
+			return s
+		})
+		_, err = out.WriteString(t + "\n")
+	}
+	if err == nil {
+		err = scanner.Err()
+	}
+	return err
+}
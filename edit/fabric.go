@@ -0,0 +1,62 @@
+package edit
+
+import (
+	"github.com/mrmelon54/mcmodupdater/develop"
+	"io"
+	"strconv"
+)
+
+// fabricDependencyIds maps fabric.mod.json dependency ids to properties
+var fabricDependencyIds = map[string]develop.PropVersion{
+	"minecraft":    develop.MinecraftVersion,
+	"fabricloader": develop.FabricLoaderVersion,
+	"fabric-api":   develop.FabricApiVersion,
+	"fabric":       develop.FabricApiVersion,
+	"architectury": develop.ArchitecturyVersion,
+	// Legacy Fabric
+	"legacy-fabric-api": develop.LegacyFabricApiVersion,
+	// Ornithe
+	"osl": develop.OslVersion,
+}
+
+// FabricModJson updates the constraints in the "depends" block of a
+// fabric.mod.json file without changing the rest of the file
+func FabricModJson(out io.StringWriter, in io.Reader, ver map[develop.PropVersion]string, strategy string) error {
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	b, err = replaceJsonValues(b, func(v jsonValue) (string, bool) {
+		if !v.PathIs("depends", "*") {
+			return "", false
+		}
+		p, ok := fabricDependencyIds[v.Path[1]]
+		if !ok {
+			return "", false
+		}
+		return replaceConstraint(v, ver, p, strategy)
+	})
+	if err != nil {
+		return err
+	}
+	_, err = out.WriteString(string(b))
+	return err
+}
+
+// replaceConstraint formats a string constraint for the new version of the
+// property, other values are left alone
+func replaceConstraint(v jsonValue, ver map[develop.PropVersion]string, p develop.PropVersion, strategy string) (string, bool) {
+	version, ok := ver[p]
+	if !ok {
+		return "", false
+	}
+	old, ok := v.String()
+	if !ok {
+		return "", false
+	}
+	a, ok := FormatConstraint(old, version, strategy)
+	if !ok || a == old {
+		return "", false
+	}
+	return strconv.Quote(a), true
+}
@@ -0,0 +1,87 @@
+package edit
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strconv"
+)
+
+var (
+	// JavaVersion.VERSION_17 or JavaVersion.VERSION_1_8
+	javaEnumRe = regexp.MustCompile(`(\bJavaVersion\.VERSION_)(1_)?(\d+)\b`)
+	// JavaLanguageVersion.of(17)
+	javaToolchainRe = regexp.MustCompile(`(\bJavaLanguageVersion\.of\(\s*)(\d+)(\s*\))`)
+	// options.release = 17 or options.release.set(17)
+	javaReleaseRe = regexp.MustCompile(`(\brelease(?:\s*=\s*|\.set\(\s*))(\d+)\b`)
+	// JvmTarget.JVM_17
+	javaJvmTargetRe = regexp.MustCompile(`(\bJvmTarget\.JVM_)(1_)?(\d+)\b`)
+	// "java": ">=17" in fabric.mod.json
+	javaModJsonRe = regexp.MustCompile(`("java"\s*:\s*"\s*[<>=~^]*\s*)(\d+)([^"]*")`)
+
+	// the patterns to read from with the index of the version group
+	javaBuildScriptReads = []struct {
+		re    *regexp.Regexp
+		group int
+	}{
+		{javaToolchainRe, 2},
+		{javaReleaseRe, 2},
+		{javaEnumRe, 3},
+		{javaJvmTargetRe, 3},
+	}
+)
+
+// ReadJavaVersion finds the first Java version set in a build script
+func ReadJavaVersion(in io.Reader) (string, error) {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		t := scanner.Text()
+		for _, i := range javaBuildScriptReads {
+			if m := i.re.FindStringSubmatch(t); m != nil {
+				return m[i.group], nil
+			}
+		}
+	}
+	return "", scanner.Err()
+}
+
+// JavaBuildScript updates the source/target compatibility, toolchain and
+// release options in a build script
+func JavaBuildScript(out io.StringWriter, in io.Reader, version string) (err error) {
+	major, err := strconv.Atoi(version)
+	if err != nil {
+		return err
+	}
+	enumVersion := version
+	if major <= 8 {
+		enumVersion = "1_" + version
+	}
+
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() && err == nil {
+		t := scanner.Text()
+		t = javaEnumRe.ReplaceAllString(t, "${1}"+enumVersion)
+		t = javaJvmTargetRe.ReplaceAllString(t, "${1}"+enumVersion)
+		t = javaToolchainRe.ReplaceAllString(t, "${1}"+version+"${3}")
+		t = javaReleaseRe.ReplaceAllString(t, "${1}"+version)
+		_, err = out.WriteString(t + "\n")
+	}
+	if err == nil {
+		err = scanner.Err()
+	}
+	return err
+}
+
+// JavaModJson updates the "java" dependency constraint in fabric.mod.json
+// without changing the rest of the file
+func JavaModJson(out io.StringWriter, in io.Reader, version string) (err error) {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() && err == nil {
+		t := javaModJsonRe.ReplaceAllString(scanner.Text(), "${1}"+version+"${3}")
+		_, err = out.WriteString(t + "\n")
+	}
+	if err == nil {
+		err = scanner.Err()
+	}
+	return err
+}
@@ -0,0 +1,231 @@
+package edit
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// jsonValue is a value found while scanning a JSON document, Start and End
+// are the byte offsets of the raw value so it can be replaced in place
+type jsonValue struct {
+	Path       []string
+	Start, End int
+	Raw        string
+}
+
+// String returns the unquoted value for strings
+func (v jsonValue) String() (string, bool) {
+	if len(v.Raw) < 2 || v.Raw[0] != '"' {
+		return "", false
+	}
+	s, err := strconv.Unquote(v.Raw)
+	return s, err == nil
+}
+
+// PathIs compares the path, "*" matches any key or index
+func (v jsonValue) PathIs(path ...string) bool {
+	if len(v.Path) != len(path) {
+		return false
+	}
+	for n, i := range path {
+		if i != "*" && i != v.Path[n] {
+			return false
+		}
+	}
+	return true
+}
+
+// scanJson returns every value in the document ordered by position, objects
+// and arrays come before the values they contain
+func scanJson(b []byte) ([]jsonValue, error) {
+	s := &jsonScanner{b: b}
+	s.skipSpace()
+	err := s.value(nil)
+	if err != nil {
+		return nil, err
+	}
+	s.skipSpace()
+	if s.pos != len(b) {
+		return nil, fmt.Errorf("json: unexpected data at offset %d", s.pos)
+	}
+	sort.SliceStable(s.values, func(i, j int) bool { return s.values[i].Start < s.values[j].Start })
+	return s.values, nil
+}
+
+// replaceJsonValues calls repl for every value in the document and replaces
+// the raw value with the result, formatting outside replaced values is kept
+func replaceJsonValues(b []byte, repl func(v jsonValue) (string, bool)) ([]byte, error) {
+	values, err := scanJson(b)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(b))
+	last := 0
+	for _, v := range values {
+		// skip values inside an already replaced value
+		if v.Start < last {
+			continue
+		}
+		a, ok := repl(v)
+		if !ok {
+			continue
+		}
+		out = append(out, b[last:v.Start]...)
+		out = append(out, a...)
+		last = v.End
+	}
+	return append(out, b[last:]...), nil
+}
+
+type jsonScanner struct {
+	b      []byte
+	pos    int
+	values []jsonValue
+}
+
+func (s *jsonScanner) skipSpace() {
+	for s.pos < len(s.b) {
+		switch s.b[s.pos] {
+		case ' ', '\t', '\r', '\n':
+			s.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (s *jsonScanner) errorf(format string, a ...any) error {
+	return fmt.Errorf("json: "+format+" at offset %d", append(a, s.pos)...)
+}
+
+func (s *jsonScanner) value(path []string) error {
+	if s.pos >= len(s.b) {
+		return s.errorf("unexpected end of input")
+	}
+	start := s.pos
+	n := len(s.values)
+	s.values = append(s.values, jsonValue{Path: append([]string(nil), path...), Start: start})
+
+	var err error
+	switch c := s.b[s.pos]; {
+	case c == '{':
+		err = s.object(path)
+	case c == '[':
+		err = s.array(path)
+	case c == '"':
+		err = s.string()
+	default:
+		// numbers, booleans and null
+		for s.pos < len(s.b) && !isJsonDelim(s.b[s.pos]) {
+			s.pos++
+		}
+		if s.pos == start {
+			err = s.errorf("unexpected character %q", c)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	s.values[n].End = s.pos
+	s.values[n].Raw = string(s.b[start:s.pos])
+	return nil
+}
+
+func isJsonDelim(c byte) bool {
+	switch c {
+	case ',', '}', ']', ':', ' ', '\t', '\r', '\n':
+		return true
+	}
+	return false
+}
+
+func (s *jsonScanner) string() error {
+	s.pos++
+	for s.pos < len(s.b) {
+		switch s.b[s.pos] {
+		case '\\':
+			s.pos += 2
+			continue
+		case '"':
+			s.pos++
+			return nil
+		}
+		s.pos++
+	}
+	return s.errorf("unterminated string")
+}
+
+func (s *jsonScanner) object(path []string) error {
+	s.pos++
+	s.skipSpace()
+	if s.pos < len(s.b) && s.b[s.pos] == '}' {
+		s.pos++
+		return nil
+	}
+	for {
+		s.skipSpace()
+		if s.pos >= len(s.b) || s.b[s.pos] != '"' {
+			return s.errorf("expected object key")
+		}
+		keyStart := s.pos
+		if err := s.string(); err != nil {
+			return err
+		}
+		key, err := strconv.Unquote(string(s.b[keyStart:s.pos]))
+		if err != nil {
+			return s.errorf("invalid object key")
+		}
+		s.skipSpace()
+		if s.pos >= len(s.b) || s.b[s.pos] != ':' {
+			return s.errorf("expected ':'")
+		}
+		s.pos++
+		s.skipSpace()
+		if err := s.value(append(path, key)); err != nil {
+			return err
+		}
+		s.skipSpace()
+		if s.pos >= len(s.b) {
+			return s.errorf("unexpected end of input")
+		}
+		switch s.b[s.pos] {
+		case ',':
+			s.pos++
+		case '}':
+			s.pos++
+			return nil
+		default:
+			return s.errorf("expected ',' or '}'")
+		}
+	}
+}
+
+func (s *jsonScanner) array(path []string) error {
+	s.pos++
+	s.skipSpace()
+	if s.pos < len(s.b) && s.b[s.pos] == ']' {
+		s.pos++
+		return nil
+	}
+	for n := 0; ; n++ {
+		s.skipSpace()
+		if err := s.value(append(path, strconv.Itoa(n))); err != nil {
+			return err
+		}
+		s.skipSpace()
+		if s.pos >= len(s.b) {
+			return s.errorf("unexpected end of input")
+		}
+		switch s.b[s.pos] {
+		case ',':
+			s.pos++
+		case ']':
+			s.pos++
+			return nil
+		default:
+			return s.errorf("expected ',' or ']'")
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package edit
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var (
+	// minecraft { used by ForgeGradle 2 and older
+	legacyForgeBlockRe = regexp.MustCompile(`^\s*minecraft\s*\{`)
+	// version = "1.12.2-14.23.5.2860", mappings = "stable_39" or the older
+	// mcpVersion = "snapshot_20140925"
+	legacyForgeValueRe = regexp.MustCompile(`^(\s*(version|mappings|mcpVersion)\s*=?\s*)(["'])([^"'$]*)(["'].*)$`)
+)
+
+// LegacyForgeBlock contains the literal values from the minecraft block of a
+// ForgeGradle 2 build script, interpolated values are ignored
+type LegacyForgeBlock struct {
+	// Version is the combined mc-forge version
+	Version    string
+	Mappings   string
+	McpVersion string
+}
+
+// ReadLegacyForgeBlock reads the version and mappings from the minecraft block
+func ReadLegacyForgeBlock(in io.Reader) (LegacyForgeBlock, error) {
+	var a LegacyForgeBlock
+	err := scanLegacyForgeBlock(in, func(t string, m []string) error {
+		switch m[2] {
+		case "version":
+			a.Version = m[4]
+		case "mappings":
+			a.Mappings = m[4]
+		case "mcpVersion":
+			a.McpVersion = m[4]
+		}
+		return nil
+	}, nil)
+	return a, err
+}
+
+// LegacyForgeBuildScript updates the version and mappings in the minecraft
+// block, mcpVersion is updated with the mappings. Empty values are left
+// unchanged
+func LegacyForgeBuildScript(out io.StringWriter, in io.Reader, version, mappings string) error {
+	write := func(t string) error {
+		_, err := out.WriteString(t + "\n")
+		return err
+	}
+	return scanLegacyForgeBlock(in, func(t string, m []string) error {
+		v := map[string]string{"version": version, "mappings": mappings, "mcpVersion": mappings}[m[2]]
+		if v == "" {
+			return write(t)
+		}
+		return write(m[1] + m[3] + v + m[5])
+	}, write)
+}
+
+// scanLegacyForgeBlock calls value for version and mappings lines inside the
+// minecraft block and other for every other line
+func scanLegacyForgeBlock(in io.Reader, value func(t string, m []string) error, other func(t string) error) error {
+	scanner := bufio.NewScanner(in)
+	depth := 0
+	for scanner.Scan() {
+		t := scanner.Text()
+		if depth == 0 && legacyForgeBlockRe.MatchString(t) {
+			depth = strings.Count(t, "{") - strings.Count(t, "}")
+		} else if depth > 0 {
+			if m := legacyForgeValueRe.FindStringSubmatch(t); m != nil && depth == 1 {
+				if err := value(t, m); err != nil {
+					return err
+				}
+				continue
+			}
+			depth += strings.Count(t, "{") - strings.Count(t, "}")
+		}
+		if other != nil {
+			if err := other(t); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
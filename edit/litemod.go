@@ -0,0 +1,29 @@
+package edit
+
+import (
+	"io"
+	"strconv"
+)
+
+// LiteModJson updates the "mcversion" field of a litemod.json file without
+// changing the rest of the file
+func LiteModJson(out io.StringWriter, in io.Reader, mcVersion string) error {
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	b, err = replaceJsonValues(b, func(v jsonValue) (string, bool) {
+		if !v.PathIs("mcversion") {
+			return "", false
+		}
+		if old, ok := v.String(); !ok || old == mcVersion {
+			return "", false
+		}
+		return strconv.Quote(mcVersion), true
+	})
+	if err != nil {
+		return err
+	}
+	_, err = out.WriteString(string(b))
+	return err
+}
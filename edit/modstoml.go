@@ -0,0 +1,186 @@
+package edit
+
+import (
+	"bufio"
+	"github.com/mrmelon54/mcmodupdater/develop"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ModsTomlNames are the Forge and NeoForge metadata files inside META-INF
+var ModsTomlNames = []string{
+	"mods.toml",
+	"neoforge.mods.toml",
+}
+
+var (
+	tomlHeaderRe     = regexp.MustCompile(`^\s*\[\[?\s*([^\]]+?)\s*]]?`)
+	tomlStringPairRe = regexp.MustCompile(`^(\s*([\w\-]+)\s*=\s*(["']))([^"']*)(["'].*)$`)
+	mavenRangeRe     = regexp.MustCompile(`^([\[(])\s*([^,\])]*?)\s*(?:(,)\s*([^\])]*?)\s*)?([\])])$`)
+)
+
+// ModsToml updates the loaderVersion and the dependency version ranges for
+// minecraft, forge, neoforge and architectury in mods.toml files. Comments are preserved
+// and placeholders expanded by gradle are skipped.
+func ModsToml(out io.StringWriter, in io.Reader, ver map[develop.PropVersion]string) error {
+	mc := ver[develop.MinecraftVersion]
+	deps := map[string]string{
+		"minecraft":    mc,
+		"forge":        loaderVersionWithoutMc(ver[develop.ForgeVersion], mc),
+		"neoforge":     ver[develop.NeoForgeVersion],
+		"architectury": ver[develop.ArchitecturyVersion],
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	// the javafml loader version matches the forge major version, this is
+	// skipped for neoforge which uses its own FML versions
+	loaderMajor, _, _ := strings.Cut(deps["forge"], ".")
+	for _, t := range lines {
+		if m := tomlStringPairRe.FindStringSubmatch(t); m != nil && m[2] == "modId" && m[4] == "neoforge" {
+			loaderMajor = ""
+		}
+	}
+
+	var block []string
+	inDependency := false
+	flush := func() error {
+		if inDependency {
+			block = updateDependencyBlock(block, deps)
+		}
+		for _, i := range block {
+			if _, err := out.WriteString(i + "\n"); err != nil {
+				return err
+			}
+		}
+		block = block[:0]
+		return nil
+	}
+
+	for _, t := range lines {
+		if m := tomlHeaderRe.FindStringSubmatch(t); m != nil {
+			if err := flush(); err != nil {
+				return err
+			}
+			inDependency = strings.HasPrefix(m[1], "dependencies.")
+		} else if m := tomlStringPairRe.FindStringSubmatch(t); m != nil && m[2] == "loaderVersion" && !inDependency && loaderMajor != "" {
+			if a, ok := UpdateMavenRange(m[4], loaderMajor); ok {
+				t = m[1] + a + m[5]
+			}
+		}
+		block = append(block, t)
+	}
+	return flush()
+}
+
+// updateDependencyBlock changes the versionRange of a [[dependencies.<modid>]]
+// entry when the modId is one of the known dependencies
+func updateDependencyBlock(block []string, deps map[string]string) []string {
+	var modId string
+	rangeLine := -1
+	for n, t := range block {
+		m := tomlStringPairRe.FindStringSubmatch(t)
+		if m == nil {
+			continue
+		}
+		switch m[2] {
+		case "modId":
+			modId = m[4]
+		case "versionRange":
+			rangeLine = n
+		}
+	}
+	version := deps[modId]
+	if rangeLine == -1 || version == "" {
+		return block
+	}
+	m := tomlStringPairRe.FindStringSubmatch(block[rangeLine])
+	if a, ok := UpdateMavenRange(m[4], version); ok {
+		block[rangeLine] = m[1] + a + m[5]
+	}
+	return block
+}
+
+// loaderVersionWithoutMc removes the "1.20.1-" prefix from forge versions
+func loaderVersionWithoutMc(v, mc string) string {
+	if mc != "" {
+		if a, ok := strings.CutPrefix(v, mc+"-"); ok {
+			return a
+		}
+	}
+	return v
+}
+
+// UpdateMavenRange moves the lower bound of a maven version range like
+// "[1.20.1,1.21)" to the version, keeping the same number of components. The
+// upper bound is raised when it would exclude the new version.
+func UpdateMavenRange(old, version string) (string, bool) {
+	if IsPlaceholder(old) || version == "" {
+		return old, false
+	}
+	m := mavenRangeRe.FindStringSubmatch(strings.TrimSpace(old))
+	if m == nil || m[2] == "" {
+		return old, false
+	}
+	lower := truncateVersion(version, len(strings.Split(m[2], ".")))
+	upper := m[4]
+	if upper != "" && compareVersions(lower, upper) >= 0 {
+		upper = nextVersion(version, len(strings.Split(upper, ".")))
+	}
+	return m[1] + lower + m[3] + upper + m[5], true
+}
+
+// truncateVersion keeps the first n components of the version, the last
+// component keeps any suffix like "-beta"
+func truncateVersion(v string, n int) string {
+	parts := strings.Split(v, ".")
+	if n >= len(parts) {
+		return v
+	}
+	return strings.Join(parts[:n], ".")
+}
+
+// nextVersion increments the last of the first n components
+func nextVersion(v string, n int) string {
+	parts := strings.Split(truncateVersion(v, n), ".")
+	last := parts[len(parts)-1]
+	if i := strings.IndexFunc(last, func(r rune) bool { return r < '0' || r > '9' }); i != -1 {
+		last = last[:i]
+	}
+	a, err := strconv.Atoi(last)
+	if err != nil {
+		return v
+	}
+	parts[len(parts)-1] = strconv.Itoa(a + 1)
+	return strings.Join(parts, ".")
+}
+
+// compareVersions compares dotted versions numerically
+func compareVersions(a, b string) int {
+	pa, pb := strings.Split(a, "."), strings.Split(b, ".")
+	for n := 0; n < len(pa) || n < len(pb); n++ {
+		var x, y int
+		if n < len(pa) {
+			x, _ = strconv.Atoi(strings.SplitN(pa[n], "-", 2)[0])
+		}
+		if n < len(pb) {
+			y, _ = strconv.Atoi(strings.SplitN(pb[n], "-", 2)[0])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,57 @@
+package edit
+
+import (
+	"io"
+	"strconv"
+)
+
+// PackMcmetaName is the metadata file of resource and data packs
+const PackMcmetaName = "pack.mcmeta"
+
+// PackMcmeta updates "pack_format" and raises the top of "supported_formats"
+// in a pack.mcmeta file, the Forge specific data pack keys use dataFormat
+func PackMcmeta(out io.StringWriter, in io.Reader, format, dataFormat int) error {
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	b, err = replaceJsonValues(b, func(v jsonValue) (string, bool) {
+		switch {
+		case v.PathIs("pack", "pack_format"):
+			return replaceFormat(v, format)
+		case v.PathIs("pack", "forge:data_pack_format"), v.PathIs("pack", "forge:server_data_pack_format"):
+			return replaceFormat(v, dataFormat)
+		case v.PathIs("pack", "forge:resource_pack_format"):
+			return replaceFormat(v, format)
+		case v.PathIs("pack", "supported_formats"):
+			// a single number is the same as pack_format
+			return replaceFormat(v, format)
+		case v.PathIs("pack", "supported_formats", "1"), v.PathIs("pack", "supported_formats", "max_inclusive"):
+			return raiseFormat(v, format)
+		}
+		return "", false
+	})
+	if err != nil {
+		return err
+	}
+	_, err = out.WriteString(string(b))
+	return err
+}
+
+// replaceFormat changes a number value, other values are left alone
+func replaceFormat(v jsonValue, format int) (string, bool) {
+	n, err := strconv.Atoi(v.Raw)
+	if err != nil || format == 0 || n == format {
+		return "", false
+	}
+	return strconv.Itoa(format), true
+}
+
+// raiseFormat changes the upper bound of a range when it is below format
+func raiseFormat(v jsonValue, format int) (string, bool) {
+	n, err := strconv.Atoi(v.Raw)
+	if err != nil || n >= format {
+		return "", false
+	}
+	return strconv.Itoa(format), true
+}
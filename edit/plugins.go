@@ -0,0 +1,66 @@
+package edit
+
+import (
+	"bufio"
+	"github.com/mrmelon54/mcmodupdater/develop"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// GradleBuildPaths are the build scripts which may contain a plugins block
+var GradleBuildPaths = []string{
+	"settings.gradle",
+	"settings.gradle.kts",
+	"build.gradle",
+	"build.gradle.kts",
+}
+
+// matches `id 'fabric-loom' version '1.5-SNAPSHOT'` and `id("fabric-loom") version "1.5-SNAPSHOT"`,
+// versions using variables or string templates are skipped
+var pluginLineRe = regexp.MustCompile(`(\bid\s*\(?\s*["']([\w.\-]+)["']\s*\)?\s+version\s*\(?\s*["'])([^"'$]+)(["'])`)
+
+// ReadGradlePlugins finds the plugin versions declared in a plugins block
+func ReadGradlePlugins(in io.Reader) (map[develop.PropVersion]string, error) {
+	a := make(map[develop.PropVersion]string)
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		for _, m := range pluginLineRe.FindAllStringSubmatch(scanner.Text(), -1) {
+			if p, ok := develop.PropVersionFromPluginId(m[2]); ok && !IsDynamicVersion(m[3]) {
+				a[p] = m[3]
+			}
+		}
+	}
+	return a, scanner.Err()
+}
+
+// GradlePlugins updates the plugin versions declared in a plugins block
+func GradlePlugins(out io.StringWriter, in io.Reader, ver map[develop.PropVersion]string) (err error) {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() && err == nil {
+		t := pluginLineRe.ReplaceAllStringFunc(scanner.Text(), func(s string) string {
+			m := pluginLineRe.FindStringSubmatch(s)
+			if IsDynamicVersion(m[3]) {
+				return s
+			}
+			if p, ok := develop.PropVersionFromPluginId(m[2]); ok {
+				if v, ok := ver[p]; ok {
+					return m[1] + v + m[4]
+				}
+			}
+			return s
+		})
+		_, err = out.WriteString(t + "\n")
+	}
+	if err == nil {
+		err = scanner.Err()
+	}
+	return err
+}
+
+// IsDynamicVersion reports whether the version is a range like "[6.0,6.2)" or
+// a prefix like "6.0.+", these are left for gradle to resolve. Build metadata
+// like "0.91.0+1.20.1" is part of a fixed version.
+func IsDynamicVersion(v string) bool {
+	return strings.HasSuffix(v, "+") || strings.ContainsAny(v, "[](),") || v == "latest.release" || v == "latest.integration"
+}
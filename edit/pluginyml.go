@@ -0,0 +1,40 @@
+package edit
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// PluginYmlNames are the Bukkit and Paper plugin manifests
+var PluginYmlNames = []string{"plugin.yml", "paper-plugin.yml"}
+
+// api-version: '1.20' at the top level of the manifest
+var pluginYmlApiVersionRe = regexp.MustCompile(`^(api-version\s*:\s*["']?)([\d.]+)(["']?.*)$`)
+
+// PluginYml updates the "api-version" field, the number of version parts
+// already used in the manifest is kept
+func PluginYml(out io.StringWriter, in io.Reader, mcVersion string) (err error) {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() && err == nil {
+		t := scanner.Text()
+		if m := pluginYmlApiVersionRe.FindStringSubmatch(t); m != nil {
+			t = m[1] + trimVersionParts(mcVersion, strings.Count(m[2], ".")+1) + m[3]
+		}
+		_, err = out.WriteString(t + "\n")
+	}
+	if err == nil {
+		err = scanner.Err()
+	}
+	return err
+}
+
+// trimVersionParts keeps the first n dot separated parts of the version
+func trimVersionParts(v string, n int) string {
+	a := strings.SplitN(v, ".", n+1)
+	if len(a) > n {
+		a = a[:n]
+	}
+	return strings.Join(a, ".")
+}
@@ -0,0 +1,72 @@
+package edit
+
+import (
+	"github.com/mrmelon54/mcmodupdater/develop"
+	"io"
+	"strconv"
+)
+
+// quiltDependencyIds maps quilt.mod.json dependency ids to properties
+var quiltDependencyIds = map[string]develop.PropVersion{
+	"minecraft":          develop.MinecraftVersion,
+	"quilt_loader":       develop.QuiltLoaderVersion,
+	"quilted_fabric_api": develop.QuiltFabricApiVersion,
+	"architectury":       develop.ArchitecturyVersion,
+}
+
+// QuiltModJson updates the constraints in quilt_loader.depends, both the
+// string form "minecraft" and the object form {"id": ..., "versions": ...}
+// are supported. String dependencies have no constraint so they are only
+// replaced with the object form when the strategy isn't ConstraintKeep.
+func QuiltModJson(out io.StringWriter, in io.Reader, ver map[develop.PropVersion]string, strategy string) error {
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	values, err := scanJson(b)
+	if err != nil {
+		return err
+	}
+
+	// find the id of each dependency in object form
+	ids := make(map[string]string)
+	for _, v := range values {
+		if v.PathIs("quilt_loader", "depends", "*", "id") {
+			if id, ok := v.String(); ok {
+				ids[v.Path[2]] = id
+			}
+		}
+	}
+
+	b, err = replaceJsonValues(b, func(v jsonValue) (string, bool) {
+		if v.PathIs("quilt_loader", "depends", "*") {
+			id, ok := v.String()
+			if !ok || strategy == ConstraintKeep {
+				return "", false
+			}
+			p, ok := quiltDependencyIds[id]
+			if !ok {
+				return "", false
+			}
+			version, ok := ver[p]
+			if !ok {
+				return "", false
+			}
+			a, _ := FormatConstraint("*", version, strategy)
+			return `{"id": ` + strconv.Quote(id) + `, "versions": ` + strconv.Quote(a) + `}`, true
+		}
+		if v.PathIs("quilt_loader", "depends", "*", "versions") {
+			p, ok := quiltDependencyIds[ids[v.Path[2]]]
+			if !ok {
+				return "", false
+			}
+			return replaceConstraint(v, ver, p, strategy)
+		}
+		return "", false
+	})
+	if err != nil {
+		return err
+	}
+	_, err = out.WriteString(string(b))
+	return err
+}
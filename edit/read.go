@@ -0,0 +1,22 @@
+package edit
+
+import (
+	"io"
+	"io/fs"
+)
+
+// ReadFirst calls read with each file which exists until it finds a value
+func ReadFirst(tree fs.FS, names []string, read func(io.Reader) (string, error)) string {
+	for _, i := range names {
+		f, err := tree.Open(i)
+		if err != nil {
+			continue
+		}
+		a, err := read(f)
+		_ = f.Close()
+		if err == nil && a != "" {
+			return a
+		}
+	}
+	return ""
+}
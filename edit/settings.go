@@ -0,0 +1,49 @@
+package edit
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// GradleSettingsPaths are the settings scripts which include sub-projects
+var GradleSettingsPaths = []string{
+	"settings.gradle",
+	"settings.gradle.kts",
+}
+
+var (
+	// matches `include 'fabric', ':forge'` and `include("fabric", "forge")`,
+	// the parenthesised form may span several lines
+	settingsIncludeRe = regexp.MustCompile(`^\s*include\b\s*(\(?)(.*)$`)
+	settingsProjectRe = regexp.MustCompile(`["']:?([^"'$]+)["']`)
+)
+
+// ReadGradleIncludes finds the sub-project paths included by the settings
+// script, nested projects use slashes, e.g. ":forge:common" is "forge/common".
+// Includes built from variables are skipped.
+func ReadGradleIncludes(in io.Reader) ([]string, error) {
+	a := make([]string, 0)
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		m := settingsIncludeRe.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		args := m[2]
+		if m[1] == "(" {
+			// collect the arguments up to the closing parenthesis
+			depth := 1 + strings.Count(args, "(") - strings.Count(args, ")")
+			for depth > 0 && scanner.Scan() {
+				t := scanner.Text()
+				args += "\n" + t
+				depth += strings.Count(t, "(") - strings.Count(t, ")")
+			}
+		}
+		for _, i := range settingsProjectRe.FindAllStringSubmatch(args, -1) {
+			a = append(a, strings.ReplaceAll(i[1], ":", "/"))
+		}
+	}
+	return a, scanner.Err()
+}
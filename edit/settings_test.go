@@ -0,0 +1,29 @@
+package edit
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadGradleIncludes(t *testing.T) {
+	for _, i := range []struct {
+		name     string
+		settings string
+		want     []string
+	}{
+		{"groovy", "rootProject.name = 'example'\ninclude 'common', ':fabric'\ninclude ':forge'\n", []string{"common", "fabric", "forge"}},
+		{"kotlin", "include(\"common\", \"fabric\")\ninclude(\"neoforge\")\n", []string{"common", "fabric", "neoforge"}},
+		{"multi-line", "include(\n    \"common\",\n    \"fabric\",\n    \"neoforge\"\n)\nrootProject.name = \"example\"\n", []string{"common", "fabric", "neoforge"}},
+		{"nested", "include(\":forge:common\")\n", []string{"forge/common"}},
+		{"variables", "for (p in enabled_platforms.split(\",\")) include(p)\ninclude(\"${p}\")\n", []string{}},
+	} {
+		got, err := ReadGradleIncludes(strings.NewReader(i.settings))
+		if err != nil {
+			t.Fatalf("%s: %v", i.name, err)
+		}
+		if !reflect.DeepEqual(got, i.want) {
+			t.Errorf("%s: ReadGradleIncludes() = %q; want %q", i.name, got, i.want)
+		}
+	}
+}
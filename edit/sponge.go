@@ -0,0 +1,70 @@
+package edit
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var (
+	// sponge { used by the SpongeGradle plugin
+	spongeBlockRe = regexp.MustCompile(`^\s*sponge\s*\{`)
+	// apiVersion("8.2.0") or apiVersion = "8.2.0" inside the sponge block
+	spongeApiVersionRe = regexp.MustCompile(`^(\s*apiVersion\s*(?:\(|=)?\s*["'])([^"'$]+)(["'].*)$`)
+	// sponge.apiVersion("8.2.0") outside the sponge block
+	spongeDotApiVersionRe = regexp.MustCompile(`(\bsponge\.apiVersion\s*(?:\(|=)?\s*["'])([^"'$]+)(["'])`)
+)
+
+// ReadSpongeApiVersion finds the API version set for the SpongeGradle plugin
+func ReadSpongeApiVersion(in io.Reader) (string, error) {
+	var a string
+	err := scanSpongeBlock(in, func(t string, re *regexp.Regexp) error {
+		if re == nil || a != "" {
+			return nil
+		}
+		if m := re.FindStringSubmatch(t); m != nil {
+			a = m[2]
+		}
+		return nil
+	})
+	return a, err
+}
+
+// SpongeBuildScript updates the API version set for the SpongeGradle plugin
+func SpongeBuildScript(out io.StringWriter, in io.Reader, version string) error {
+	return scanSpongeBlock(in, func(t string, re *regexp.Regexp) error {
+		if re != nil {
+			t = re.ReplaceAllString(t, "${1}"+escapeReplacement(version)+"${3}")
+		}
+		_, err := out.WriteString(t + "\n")
+		return err
+	})
+}
+
+// scanSpongeBlock calls line for every line with the regexp matching the API
+// version at that position, this is nil for lines which can't contain it.
+// Other plugins also use apiVersion, e.g. the Kotlin compiler options, so only
+// the top level of the sponge block is matched.
+func scanSpongeBlock(in io.Reader, line func(t string, re *regexp.Regexp) error) error {
+	scanner := bufio.NewScanner(in)
+	depth := 0
+	for scanner.Scan() {
+		t := scanner.Text()
+		var re *regexp.Regexp
+		switch {
+		case depth == 0 && spongeBlockRe.MatchString(t):
+			depth = strings.Count(t, "{") - strings.Count(t, "}")
+		case depth == 1 && spongeApiVersionRe.MatchString(t):
+			re = spongeApiVersionRe
+		case depth > 0:
+			depth += strings.Count(t, "{") - strings.Count(t, "}")
+		case spongeDotApiVersionRe.MatchString(t):
+			re = spongeDotApiVersionRe
+		}
+		if err := line(t, re); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
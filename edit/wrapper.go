@@ -0,0 +1,64 @@
+package edit
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+)
+
+const GradleWrapperPath = "gradle/wrapper/gradle-wrapper.properties"
+
+var wrapperDistributionRe = regexp.MustCompile(`(gradle-)([^/]+?)(-(bin|all)\.zip)`)
+
+// GradleWrapperInfo is the distribution used by the gradle wrapper
+type GradleWrapperInfo struct {
+	Version string
+	Type    string
+	HasSha  bool
+}
+
+func ReadGradleWrapper(in io.Reader) (GradleWrapperInfo, error) {
+	var a GradleWrapperInfo
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		k, v, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(k) {
+		case "distributionUrl":
+			if m := wrapperDistributionRe.FindStringSubmatch(v); m != nil {
+				a.Version, a.Type = m[2], m[4]
+			}
+		case "distributionSha256Sum":
+			a.HasSha = true
+		}
+	}
+	return a, scanner.Err()
+}
+
+// GradleWrapper updates the version in distributionUrl, and the checksum in
+// distributionSha256Sum if the property exists and sha is not empty
+func GradleWrapper(out io.StringWriter, in io.Reader, version, sha string) (err error) {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() && err == nil {
+		t := scanner.Text()
+		k, _, ok := strings.Cut(t, "=")
+		if ok {
+			switch strings.TrimSpace(k) {
+			case "distributionUrl":
+				t = wrapperDistributionRe.ReplaceAllString(t, "${1}"+escapeReplacement(version)+"${3}")
+			case "distributionSha256Sum":
+				if sha != "" {
+					t = k + "=" + sha
+				}
+			}
+		}
+		_, err = out.WriteString(t + "\n")
+	}
+	if err == nil {
+		err = scanner.Err()
+	}
+	return err
+}
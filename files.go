@@ -0,0 +1,224 @@
+package mcmodupdater
+
+import (
+	"bytes"
+	"github.com/mrmelon54/mcmodupdater/develop"
+	"github.com/mrmelon54/mcmodupdater/develop/dev"
+	"github.com/mrmelon54/mcmodupdater/edit"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// modResourceDirs are the folders containing mod metadata files
+var modResourceDirs = []string{
+	"src/main/resources",
+	"resources",
+}
+
+// FileUpdate rewrites one file in the project tree, these are applied along
+// with the properties file
+type FileUpdate struct {
+	Name   string
+	Update func(out io.StringWriter, in io.Reader) error
+}
+
+type fileUpdates []FileUpdate
+
+// add appends the update if the file exists, multiple updates for the same
+// file are chained together
+func (a *fileUpdates) add(tree fs.FS, name string, update func(out io.StringWriter, in io.Reader) error) {
+	if !fileExists(tree, name) {
+		return
+	}
+	for n, i := range *a {
+		if i.Name != name {
+			continue
+		}
+		prev := i.Update
+		(*a)[n].Update = func(out io.StringWriter, in io.Reader) error {
+			var b strings.Builder
+			if err := prev(&b, in); err != nil {
+				return err
+			}
+			return update(out, strings.NewReader(b.String()))
+		}
+		return
+	}
+	*a = append(*a, FileUpdate{name, update})
+}
+
+// FileUpdates returns the updates for the other files in the project tree
+// which contain versions, mod metadata is only changed for updated versions
+func (m *McModUpdater) FileUpdates(tree fs.FS, list VersionUpdateList) []FileUpdate {
+	ver := list.ChangeToLatest()
+	updated := list.Updated().ChangeToLatest()
+
+	a := make(fileUpdates, 0)
+	for _, i := range edit.GradleBuildPaths {
+		a.add(tree, i, func(out io.StringWriter, in io.Reader) error {
+			return edit.GradlePlugins(out, in, ver)
+		})
+	}
+	for _, dir := range projectDirs(tree) {
+		for _, i := range edit.GradleBuildPaths {
+			a.add(tree, path.Join(dir, i), func(out io.StringWriter, in io.Reader) error {
+				return edit.GradleDependencies(out, in, ver)
+			})
+		}
+	}
+	if v, ok := updated[develop.SpongeApiVersion]; ok {
+		for _, i := range edit.GradleBuildPaths {
+			a.add(tree, i, func(out io.StringWriter, in io.Reader) error {
+				return edit.SpongeBuildScript(out, in, v)
+			})
+		}
+	}
+	a.add(tree, edit.GradleVersionCatalogPath, func(out io.StringWriter, in io.Reader) error {
+		return edit.VersionCatalog(out, in, ver)
+	})
+	if v, ok := ver[develop.GradleVersion]; ok {
+		a.add(tree, edit.GradleWrapperPath, func(out io.StringWriter, in io.Reader) error {
+			return m.updateGradleWrapper(out, in, v)
+		})
+	}
+	if v, ok := updated[develop.JavaVersion]; ok {
+		for _, dir := range projectDirs(tree) {
+			for _, i := range edit.GradleBuildPaths {
+				a.add(tree, path.Join(dir, i), func(out io.StringWriter, in io.Reader) error {
+					return edit.JavaBuildScript(out, in, v)
+				})
+			}
+			for _, i := range modResourceDirs {
+				a.add(tree, path.Join(dir, i, "fabric.mod.json"), func(out io.StringWriter, in io.Reader) error {
+					return edit.JavaModJson(out, in, v)
+				})
+			}
+		}
+	}
+	for _, dir := range projectDirs(tree) {
+		for _, i := range modResourceDirs {
+			a.add(tree, path.Join(dir, i, "fabric.mod.json"), func(out io.StringWriter, in io.Reader) error {
+				return edit.FabricModJson(out, in, updated, m.conf.Develop.Fabric.DependencyStrategy)
+			})
+			a.add(tree, path.Join(dir, i, "quilt.mod.json"), func(out io.StringWriter, in io.Reader) error {
+				return edit.QuiltModJson(out, in, updated, m.conf.Develop.Quilt.DependencyStrategy)
+			})
+			if v, ok := updated[develop.MinecraftVersion]; ok {
+				for _, j := range edit.PluginYmlNames {
+					a.add(tree, path.Join(dir, i, j), func(out io.StringWriter, in io.Reader) error {
+						return edit.PluginYml(out, in, v)
+					})
+				}
+			}
+			for _, j := range edit.ModsTomlNames {
+				a.add(tree, path.Join(dir, i, "META-INF", j), func(out io.StringWriter, in io.Reader) error {
+					return edit.ModsToml(out, in, updated)
+				})
+			}
+		}
+	}
+	forge, mappings := updated[develop.ForgeVersion], updated[develop.ForgeMappingsVersion]
+	if forge != "" || mappings != "" {
+		a.add(tree, "build.gradle", func(out io.StringWriter, in io.Reader) error {
+			return edit.LegacyForgeBuildScript(out, in, forge, mappings)
+		})
+	}
+	if v, ok := updated[develop.MinecraftVersion]; ok {
+		for _, i := range modResourceDirs {
+			a.add(tree, path.Join(i, "litemod.json"), func(out io.StringWriter, in io.Reader) error {
+				return edit.LiteModJson(out, in, v)
+			})
+		}
+		m.addPackMcmetaUpdates(tree, &a, v)
+	}
+	return a
+}
+
+// addPackMcmetaUpdates changes the pack formats of the mod resources and any
+// built-in packs, the format depends on whether the pack contains assets
+func (m *McModUpdater) addPackMcmetaUpdates(tree fs.FS, a *fileUpdates, mcVersion string) {
+	_ = m.fetchCallsOnce(dev.PlatformMojang, m.mojang.FetchCalls())
+	resource, data, ok := m.mojang.PackFormat(mcVersion)
+	if !ok {
+		return
+	}
+	for _, dir := range projectDirs(tree) {
+		for _, i := range modResourceDirs {
+			_ = fs.WalkDir(tree, path.Join(dir, i), func(p string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return nil
+				}
+				if d.IsDir() {
+					if d.Name() == "assets" || d.Name() == "data" {
+						return fs.SkipDir
+					}
+					return nil
+				}
+				if d.Name() != edit.PackMcmetaName {
+					return nil
+				}
+				format := resource
+				packDir := path.Dir(p)
+				if !fileExists(tree, path.Join(packDir, "assets")) && fileExists(tree, path.Join(packDir, "data")) {
+					format = data
+				}
+				if format == 0 {
+					return nil
+				}
+				a.add(tree, p, func(out io.StringWriter, in io.Reader) error {
+					return edit.PackMcmeta(out, in, format, data)
+				})
+				return nil
+			})
+		}
+	}
+}
+
+// projectDirs returns the project root and any Architectury sub-projects
+func projectDirs(tree fs.FS) []string {
+	a := []string{"."}
+	for _, i := range append([]string{"common"}, subProjectNames()...) {
+		if s, err := fs.Stat(tree, i); err == nil && s.IsDir() {
+			a = append(a, i)
+		}
+	}
+	return a
+}
+
+func subProjectNames() []string {
+	a := make([]string, 0, len(dev.Platforms))
+	for _, i := range dev.Platforms {
+		if i.Sub != "" {
+			a = append(a, i.Sub)
+		}
+	}
+	return a
+}
+
+// updateGradleWrapper changes the distribution version, the checksum is only
+// fetched when the wrapper already contains one
+func (m *McModUpdater) updateGradleWrapper(out io.StringWriter, in io.Reader, version string) error {
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	w, err := edit.ReadGradleWrapper(bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	var sha string
+	if w.HasSha && w.Version != version {
+		sha, err = m.gradle.Checksum(version, w.Type)
+		if err != nil {
+			return err
+		}
+	}
+	return edit.GradleWrapper(out, bytes.NewReader(b), version, sha)
+}
+
+func fileExists(tree fs.FS, name string) bool {
+	_, err := fs.Stat(tree, name)
+	return err == nil
+}
@@ -0,0 +1,85 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Repo runs git commands using the local git CLI inside Dir
+type Repo struct {
+	Dir string
+}
+
+func Open(dir string) (*Repo, error) {
+	r := &Repo{Dir: dir}
+	out, err := r.run("rev-parse", "--is-inside-work-tree")
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(out) != "true" {
+		return nil, fmt.Errorf("'%s' is not inside a git work tree", dir)
+	}
+	return r, nil
+}
+
+// IsDirty reports whether the work tree has uncommitted or untracked changes
+func (r *Repo) IsDirty() (bool, error) {
+	out, err := r.run("status", "--porcelain")
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(out) != "", nil
+}
+
+// CheckBranch reports an error if the branch name is invalid or the branch
+// already exists
+func (r *Repo) CheckBranch(name string) error {
+	if _, err := r.run("check-ref-format", "--branch", name); err != nil {
+		return fmt.Errorf("invalid branch name '%s'", name)
+	}
+	if _, err := r.run("rev-parse", "--verify", "--quiet", "refs/heads/"+name); err == nil {
+		return fmt.Errorf("branch '%s' already exists", name)
+	}
+	return nil
+}
+
+// CreateBranch creates and switches to a new branch, uncommitted changes are
+// carried over to the new branch
+func (r *Repo) CreateBranch(name string) error {
+	if err := r.CheckBranch(name); err != nil {
+		return err
+	}
+	_, err := r.run("checkout", "-b", name)
+	return err
+}
+
+// Commit stages the files and commits them with the message
+func (r *Repo) Commit(message string, files ...string) error {
+	if len(files) == 0 {
+		return fmt.Errorf("no files to commit")
+	}
+	_, err := r.run(append([]string{"add", "--"}, files...)...)
+	if err != nil {
+		return err
+	}
+	_, err = r.run("commit", "-m", message)
+	return err
+}
+
+func (r *Repo) run(args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", append([]string{"-C", r.Dir}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			return "", fmt.Errorf("git %s: %w", args[0], err)
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], msg)
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,170 @@
+package mcmodupdater
+
+import (
+	"fmt"
+	"github.com/mrmelon54/mcmodupdater/develop"
+	"github.com/mrmelon54/mcmodupdater/develop/dev"
+	"github.com/mrmelon54/mcmodupdater/meta/shared"
+)
+
+// MatrixProps are the columns of the compatibility matrix
+var MatrixProps = []develop.PropVersion{
+	develop.FabricLoaderVersion,
+	develop.FabricApiVersion,
+	develop.YarnMappingsVersion,
+	develop.ForgeVersion,
+	develop.NeoForgeVersion,
+	develop.QuiltLoaderVersion,
+	develop.QuiltFabricApiVersion,
+	develop.QuiltMappingsVersion,
+	develop.ArchitecturyVersion,
+}
+
+// matrixPlatforms are the platforms providing each column, only these are
+// fetched so an unreachable endpoint of another platform doesn't matter
+var matrixPlatforms = map[develop.PropVersion]develop.DevPlatform{
+	develop.FabricLoaderVersion:   dev.PlatformFabric,
+	develop.FabricApiVersion:      dev.PlatformFabric,
+	develop.YarnMappingsVersion:   dev.PlatformFabric,
+	develop.ForgeVersion:          dev.PlatformForge,
+	develop.NeoForgeVersion:       dev.PlatformNeoForge,
+	develop.QuiltLoaderVersion:    dev.PlatformQuilt,
+	develop.QuiltFabricApiVersion: dev.PlatformQuilt,
+	develop.QuiltMappingsVersion:  dev.PlatformQuilt,
+	develop.ArchitecturyVersion:   dev.PlatformArchitectury,
+}
+
+type MatrixRow struct {
+	Minecraft string
+	Versions  map[develop.PropVersion]string
+}
+
+// Missing returns the properties without a release for this Minecraft version
+func (r MatrixRow) Missing(props []develop.PropVersion) []develop.PropVersion {
+	a := make([]develop.PropVersion, 0)
+	for _, i := range props {
+		if r.Versions[i] == "" {
+			a = append(a, i)
+		}
+	}
+	return a
+}
+
+// GameVersions returns the Minecraft versions from the Fabric game meta,
+// newest first
+func (m *McModUpdater) GameVersions() ([]shared.GameVersionMeta, error) {
+	f, ok := m.platforms[dev.PlatformFabric].(*dev.Fabric)
+	if !ok {
+		return nil, fmt.Errorf("fabric platform is required for the game meta")
+	}
+	err := m.Fetch(f)
+	if err != nil {
+		return nil, err
+	}
+	return f.Meta.Game, nil
+}
+
+// GameVersionRange returns the versions between from and to inclusive, empty
+// bounds include the newest or oldest versions
+func GameVersionRange(all []shared.GameVersionMeta, from, to string, unstable bool) ([]string, error) {
+	start, end := 0, len(all)
+	if to != "" {
+		start = gameVersionIndex(all, to)
+		if start == -1 {
+			return nil, fmt.Errorf("unknown minecraft version: %s", to)
+		}
+	}
+	if from != "" {
+		end = gameVersionIndex(all, from)
+		if end == -1 {
+			return nil, fmt.Errorf("unknown minecraft version: %s", from)
+		}
+		end++
+	}
+	if start >= end {
+		return nil, fmt.Errorf("minecraft version %s is newer than %s", from, to)
+	}
+	a := make([]string, 0)
+	for _, i := range all[start:end] {
+		if i.Stable || unstable {
+			a = append(a, i.Version)
+		}
+	}
+	return a, nil
+}
+
+func gameVersionIndex(all []shared.GameVersionMeta, version string) int {
+	for n, i := range all {
+		if i.Version == version {
+			return n
+		}
+	}
+	return -1
+}
+
+// FetchMatrix fetches the metadata for the platforms providing the properties
+func (m *McModUpdater) FetchMatrix(props []develop.PropVersion) error {
+	fetched := make(map[develop.DevPlatform]bool)
+	for _, i := range props {
+		p, ok := m.matrixPlatform(i)
+		if !ok || fetched[p.Platform()] {
+			continue
+		}
+		fetched[p.Platform()] = true
+		err := m.Fetch(p)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matrixPlatform returns the platform providing the property in the matrix
+func (m *McModUpdater) matrixPlatform(prop develop.PropVersion) (develop.Develop, bool) {
+	p, ok := matrixPlatforms[prop]
+	if !ok {
+		return nil, false
+	}
+	if p == dev.PlatformArchitectury {
+		return m.platArch, true
+	}
+	d, ok := m.platforms[p]
+	return d, ok
+}
+
+// LatestVersion finds the latest version of the property for the Minecraft
+// version using the first platform which knows about the property
+func (m *McModUpdater) LatestVersion(prop develop.PropVersion, mcVersion string) (string, bool) {
+	if prop == develop.ArchitecturyVersion {
+		return m.platArch.LatestVersion(prop, mcVersion)
+	}
+	for _, i := range dev.Platforms {
+		if p, ok := m.platforms[i]; ok {
+			if a, ok := p.LatestVersion(prop, mcVersion); ok {
+				return a, true
+			}
+		}
+	}
+	return "", false
+}
+
+// CompatibilityMatrix finds the latest version of each property for each
+// Minecraft version using the platform providing it, FetchMatrix should be
+// called first
+func (m *McModUpdater) CompatibilityMatrix(mcVersions []string, props []develop.PropVersion) []MatrixRow {
+	a := make([]MatrixRow, len(mcVersions))
+	for n, mc := range mcVersions {
+		row := MatrixRow{Minecraft: mc, Versions: make(map[develop.PropVersion]string, len(props))}
+		for _, p := range props {
+			if d, ok := m.matrixPlatform(p); ok {
+				if l, ok := d.LatestVersion(p, mc); ok {
+					row.Versions[p] = l
+				}
+			} else if l, ok := m.LatestVersion(p, mc); ok {
+				row.Versions[p] = l
+			}
+		}
+		a[n] = row
+	}
+	return a
+}
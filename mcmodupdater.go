@@ -7,19 +7,26 @@ import (
 	"github.com/mrmelon54/mcmodupdater/config"
 	"github.com/mrmelon54/mcmodupdater/develop"
 	"github.com/mrmelon54/mcmodupdater/develop/dev"
+	"github.com/mrmelon54/mcmodupdater/edit"
 	"github.com/mrmelon54/mcmodupdater/paths"
 	"github.com/mrmelon54/mcmodupdater/utils"
 	"io"
 	"io/fs"
 	"os"
+	"path"
+	"strconv"
 	"strings"
 )
 
 type McModUpdater struct {
+	conf      *config.Config
 	cwd       string
 	cache     string
 	platforms map[develop.DevPlatform]develop.Develop
 	platArch  *dev.Architectury
+	gradle    *dev.Gradle
+	mojang    *dev.Mojang
+	fetched   map[develop.DevPlatform]bool
 }
 
 type VersionUpdateList []VersionUpdateItem
@@ -66,9 +73,13 @@ func NewMcModUpdater(conf *config.Config) (*McModUpdater, error) {
 	}
 
 	return &McModUpdater{
+		conf:      conf,
 		cache:     cache,
 		platforms: plat,
 		platArch:  dev.ForArchitectury(conf.Develop, platCache).(*dev.Architectury),
+		gradle:    dev.ForGradle(conf.Develop, platCache),
+		mojang:    dev.ForMojang(conf.Develop, platCache),
+		fetched:   make(map[develop.DevPlatform]bool),
 	}, nil
 }
 
@@ -76,8 +87,8 @@ func (m *McModUpdater) PlatArch() *dev.Architectury                        { ret
 func (m *McModUpdater) Platforms() map[develop.DevPlatform]develop.Develop { return m.platforms }
 
 func (m *McModUpdater) detectPlatformFromTree(tree fs.StatFS) (develop.Develop, bool) {
-	for _, i := range m.platforms {
-		if i.ValidTree(tree) {
+	for _, p := range dev.Platforms {
+		if i, ok := m.platforms[p]; ok && i.ValidTree(tree) {
 			return i, true
 		}
 	}
@@ -85,37 +96,50 @@ func (m *McModUpdater) detectPlatformFromTree(tree fs.StatFS) (develop.Develop,
 }
 
 func (m *McModUpdater) LoadTree(tree fs.StatFS, propsName string) (*develop.PlatformVersions, error) {
-	useArch := m.platArch.ValidTree(tree)
+	platform, err := m.detectPlatform(tree)
+	if err != nil {
+		return nil, err
+	}
 
-	var platform develop.Develop
+	versions, err := platform.ReadVersionFile(tree, propsName)
+	if err != nil {
+		return nil, err
+	}
+	mapGradleWrapper(tree, versions)
+	mapJavaVersion(tree, versions)
 
-	if useArch {
-		platform = m.platArch
-		m.platArch.SubPlatforms = make(map[develop.DevPlatform]develop.Develop, len(m.platforms))
-		for k, v := range m.platforms {
-			m.platArch.SubPlatforms[k] = v
-		}
+	info := &develop.PlatformVersions{
+		Platform:     platform,
+		Versions:     versions,
+		SubPlatforms: subPlatforms(platform),
+	}
+	err = mapForgePromotion(tree, propsName, info)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (m *McModUpdater) detectPlatform(tree fs.StatFS) (develop.Develop, error) {
+	var platform develop.Develop
+	if m.platArch.ValidTree(tree) {
+		platform = m.platArch.ForTree(tree, m.platforms)
 	} else {
-		for _, i := range m.platforms {
-			if i.ValidTree(tree) {
-				platform = i
-			}
-		}
+		platform, _ = m.detectPlatformFromTree(tree)
 	}
 
 	if platform == nil {
 		return nil, fmt.Errorf("cannot find valid platform")
 	}
+	return platform, nil
+}
 
-	versions, err := platform.ReadVersionFile(tree, propsName)
-	if err != nil {
-		return nil, err
+// subPlatforms returns the sub-platforms detected for an Architectury project
+func subPlatforms(platform develop.Develop) []develop.DevPlatform {
+	if arc, ok := platform.(*dev.Architectury); ok {
+		return arc.SubPlatformList()
 	}
-
-	return &develop.PlatformVersions{
-		Platform: platform,
-		Versions: versions,
-	}, nil
+	return nil
 }
 
 func (m *McModUpdater) VersionUpdateList(info *develop.PlatformVersions) VersionUpdateList {
@@ -125,16 +149,136 @@ func (m *McModUpdater) VersionUpdateList(info *develop.PlatformVersions) Version
 	v = m.useIfExistsUpdate(v, info, develop.ArchitecturyVersion)
 	v = m.useIfExistsUpdate(v, info, develop.FabricLoaderVersion)
 	v = m.useIfExistsUpdate(v, info, develop.FabricApiVersion)
+	v = m.useIfExistsUpdate(v, info, develop.LegacyFabricApiVersion)
 	v = m.useIfExistsUpdate(v, info, develop.YarnMappingsVersion)
-	v = m.useIfExistsUpdate(v, info, develop.ForgeVersion)
+	v = m.useIfExistsUpdate(v, info, develop.FeatherBuildVersion)
+	v = m.useIfExistsUpdate(v, info, develop.OslVersion)
+	v = m.useForgeUpdate(v, info)
 	v = m.useIfExistsUpdate(v, info, develop.ForgeMappingsVersion)
 	v = m.useIfExistsUpdate(v, info, develop.QuiltLoaderVersion)
 	v = m.useIfExistsUpdate(v, info, develop.QuiltFabricApiVersion)
 	v = m.useIfExistsUpdate(v, info, develop.QuiltMappingsVersion)
 	v = m.useIfExistsUpdate(v, info, develop.NeoForgeVersion)
+	v = m.useIfExistsUpdate(v, info, develop.LiteLoaderVersion)
+	v = m.useIfExistsUpdate(v, info, develop.PaperApiVersion)
+	v = m.useIfExistsUpdate(v, info, develop.VelocityApiVersion)
+	v = m.useIfExistsUpdate(v, info, develop.BungeeCordApiVersion)
+	v = m.useIfExistsUpdate(v, info, develop.WaterfallApiVersion)
+	v = m.useIfExistsUpdate(v, info, develop.SpongeApiVersion)
+	v = m.useIfExistsUpdate(v, info, develop.LoomVersion)
+	v = m.useIfExistsUpdate(v, info, develop.ArchitecturyLoomVersion)
+	v = m.useIfExistsUpdate(v, info, develop.ForgeGradleVersion)
+	v = m.useIfExistsUpdate(v, info, develop.NeoGradleVersion)
+	v = m.useIfExistsUpdate(v, info, develop.ModDevGradleVersion)
+	v = m.useGradleUpdate(v, info)
+	v = m.useJavaUpdate(v, info)
 	return v
 }
 
+// useJavaUpdate raises the Java version to the version required by the
+// target Minecraft version, newer Java versions are kept
+func (m *McModUpdater) useJavaUpdate(v VersionUpdateList, branch *develop.PlatformVersions) VersionUpdateList {
+	a, ok := branch.Versions[develop.JavaVersion]
+	if !ok {
+		return v
+	}
+	if m.fetchCallsOnce(dev.PlatformMojang, m.mojang.FetchCalls()) == nil {
+		required, err := m.mojang.JavaVersion(branch.Versions[develop.MinecraftVersion])
+		current, _ := strconv.Atoi(a)
+		if err == nil && required > current {
+			return append(v, VersionUpdateItem{develop.JavaVersion, a, strconv.Itoa(required)})
+		}
+	}
+	return append(v, VersionUpdateItem{develop.JavaVersion, a, ""})
+}
+
+// useForgeUpdate resolves the Forge version using the promotion chosen by the
+// project, other projects use the configured promotion
+func (m *McModUpdater) useForgeUpdate(v VersionUpdateList, branch *develop.PlatformVersions) VersionUpdateList {
+	promotion := branch.ForgePromotion
+	forge, isForge := m.platforms[dev.PlatformForge].(*dev.Forge)
+	if promotion == "" || !isForge {
+		return m.useIfExistsUpdate(v, branch, develop.ForgeVersion)
+	}
+	a, ok := branch.Versions[develop.ForgeVersion]
+	if !ok {
+		return v
+	}
+	if l, err := forge.LatestPromotedVersion(branch.Versions[develop.MinecraftVersion], promotion); err == nil && a != l {
+		return append(v, VersionUpdateItem{develop.ForgeVersion, a, l})
+	}
+	return append(v, VersionUpdateItem{develop.ForgeVersion, a, ""})
+}
+
+// useGradleUpdate resolves the gradle wrapper version, this depends on the
+// plugin versions already in the list
+func (m *McModUpdater) useGradleUpdate(v VersionUpdateList, branch *develop.PlatformVersions) VersionUpdateList {
+	a, ok := branch.Versions[develop.GradleVersion]
+	if !ok {
+		return v
+	}
+	if m.fetchCallsOnce(dev.PlatformGradle, m.gradle.FetchCalls()) == nil {
+		if l, ok := m.gradle.LatestVersion(a, v.ChangeToLatest()); ok && a != l {
+			return append(v, VersionUpdateItem{develop.GradleVersion, a, l})
+		}
+	}
+	return append(v, VersionUpdateItem{develop.GradleVersion, a, ""})
+}
+
+// mapForgePromotion reads the forge_promotion option from the properties
+// file, unknown promotions are rejected instead of falling back to latest
+func mapForgePromotion(tree fs.FS, propsName string, info *develop.PlatformVersions) error {
+	if propsName == "" {
+		propsName = "gradle.properties"
+	}
+	b, err := fs.ReadFile(tree, propsName)
+	if err != nil {
+		return nil
+	}
+	prop, err := properties.Load(b, properties.UTF8)
+	if err != nil {
+		return err
+	}
+	promotion, ok := prop.Get(dev.ForgePromotionKey)
+	if !ok {
+		return nil
+	}
+	if !dev.IsForgePromotion(promotion) {
+		return fmt.Errorf("unknown %s '%s', expected '%s' or '%s'", dev.ForgePromotionKey, promotion, dev.ForgePromotionRecommended, dev.ForgePromotionLatest)
+	}
+	info.ForgePromotion = promotion
+	return nil
+}
+
+// mapJavaVersion reads the Java version from the build scripts
+func mapJavaVersion(tree fs.FS, versions map[develop.PropVersion]string) {
+	if _, ok := versions[develop.JavaVersion]; ok {
+		return
+	}
+	names := make([]string, 0)
+	for _, dir := range projectDirs(tree) {
+		for _, i := range edit.GradleBuildPaths {
+			names = append(names, path.Join(dir, i))
+		}
+	}
+	if a := edit.ReadFirst(tree, names, edit.ReadJavaVersion); a != "" {
+		versions[develop.JavaVersion] = a
+	}
+}
+
+// mapGradleWrapper reads the gradle version from the wrapper properties
+func mapGradleWrapper(tree fs.FS, versions map[develop.PropVersion]string) {
+	f, err := tree.Open(edit.GradleWrapperPath)
+	if err != nil {
+		return
+	}
+	//goland:noinspection GoUnhandledErrorResult
+	defer f.Close()
+	if w, err := edit.ReadGradleWrapper(f); err == nil && w.Version != "" {
+		versions[develop.GradleVersion] = w.Version
+	}
+}
+
 func (m *McModUpdater) useIfExists(v VersionUpdateList, branch *develop.PlatformVersions, k develop.PropVersion) VersionUpdateList {
 	if a, ok := branch.Versions[k]; ok {
 		v = append(v, VersionUpdateItem{k, a, ""})
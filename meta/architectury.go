@@ -0,0 +1,5 @@
+package meta
+
+import "github.com/mrmelon54/mcmodupdater/meta/shared"
+
+type ArchitecturyLoomMeta shared.MavenMeta
@@ -0,0 +1,6 @@
+package meta
+
+import "github.com/mrmelon54/mcmodupdater/meta/shared"
+
+type BabricLoaderMeta []shared.LoaderVersionMeta
+type BabricMappingsMeta shared.MavenMeta
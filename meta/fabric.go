@@ -6,3 +6,4 @@ type FabricGameMeta []shared.GameVersionMeta
 type FabricYarnMeta []shared.YarnVersionMeta
 type FabricLoaderMeta []shared.LoaderVersionMeta
 type FabricApiMeta shared.MavenMeta
+type FabricLoomMeta shared.MavenMeta
@@ -3,3 +3,13 @@ package meta
 import "github.com/mrmelon54/mcmodupdater/meta/shared"
 
 type ForgeApiMeta shared.MavenMeta
+type ForgeGradleMeta shared.MavenMeta
+type ForgeMcpMeta shared.MavenMeta
+
+// ForgePromotionsMeta is promotions_slim.json, the keys of Promos are like
+// "1.20.1-recommended" and the values are Forge versions without the
+// Minecraft version
+type ForgePromotionsMeta struct {
+	Homepage string            `json:"homepage"`
+	Promos   map[string]string `json:"promos"`
+}
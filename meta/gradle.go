@@ -0,0 +1,23 @@
+package meta
+
+type GradleVersionsMeta []GradleVersionMeta
+
+type GradleVersionMeta struct {
+	Version        string `json:"version"`
+	BuildTime      string `json:"buildTime"`
+	Current        bool   `json:"current"`
+	Snapshot       bool   `json:"snapshot"`
+	Nightly        bool   `json:"nightly"`
+	ReleaseNightly bool   `json:"releaseNightly"`
+	ActiveRc       bool   `json:"activeRc"`
+	RcFor          string `json:"rcFor"`
+	MilestoneFor   string `json:"milestoneFor"`
+	Broken         bool   `json:"broken"`
+	DownloadUrl    string `json:"downloadUrl"`
+	ChecksumUrl    string `json:"checksumUrl"`
+}
+
+// IsRelease reports whether this is a final release which isn't broken
+func (g GradleVersionMeta) IsRelease() bool {
+	return !g.Snapshot && !g.Nightly && !g.ReleaseNightly && g.RcFor == "" && g.MilestoneFor == "" && !g.Broken
+}
@@ -0,0 +1,8 @@
+package meta
+
+import "github.com/mrmelon54/mcmodupdater/meta/shared"
+
+type LegacyFabricGameMeta []shared.GameVersionMeta
+type LegacyFabricYarnMeta []shared.YarnVersionMeta
+type LegacyFabricLoaderMeta []shared.LoaderVersionMeta
+type LegacyFabricApiMeta shared.MavenMeta
@@ -0,0 +1,27 @@
+package meta
+
+// LiteLoaderVersionsMeta is the versions.json file, only the fields used are
+// decoded
+type LiteLoaderVersionsMeta struct {
+	Versions map[string]LiteLoaderMcVersionMeta `json:"versions"`
+}
+
+// LiteLoaderMcVersionMeta contains the builds for one Minecraft version,
+// artefacts are release builds and snapshots are development builds
+type LiteLoaderMcVersionMeta struct {
+	Artefacts LiteLoaderArtefactsMeta `json:"artefacts,omitempty"`
+	Snapshots LiteLoaderArtefactsMeta `json:"snapshots,omitempty"`
+}
+
+// LiteLoaderArtefactsMeta contains the loader builds keyed by build id, the
+// other keys like the "libraries" array are ignored
+type LiteLoaderArtefactsMeta struct {
+	LiteLoader map[string]LiteLoaderArtefactMeta `json:"com.mumfrey:liteloader,omitempty"`
+}
+
+type LiteLoaderArtefactMeta struct {
+	Stream  string `json:"stream"`
+	File    string `json:"file"`
+	Version string `json:"version"`
+	Md5     string `json:"md5,omitempty"`
+}
@@ -0,0 +1,39 @@
+package meta
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestLiteLoaderVersionsMetaDecode(t *testing.T) {
+	f, err := os.Open("testdata/liteloader-versions.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	//goland:noinspection GoUnhandledErrorResult
+	defer f.Close()
+
+	var m LiteLoaderVersionsMeta
+	if err := json.NewDecoder(f).Decode(&m); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	for _, i := range []struct {
+		mc, artefact, snapshot string
+	}{
+		{"1.12.2", "", "1.12.2-SNAPSHOT"},
+		{"1.12", "1.12", "1.12-SNAPSHOT"},
+	} {
+		v, ok := m.Versions[i.mc]
+		if !ok {
+			t.Errorf("missing %s", i.mc)
+			continue
+		}
+		if a := v.Artefacts.LiteLoader["latest"].Version; a != i.artefact {
+			t.Errorf("%s artefact = %q; want %q", i.mc, a, i.artefact)
+		}
+		if a := v.Snapshots.LiteLoader["latest"].Version; a != i.snapshot {
+			t.Errorf("%s snapshot = %q; want %q", i.mc, a, i.snapshot)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package meta
+
+import "encoding/json"
+
+type MojangVersionManifest struct {
+	Latest   MojangLatestVersions    `json:"latest"`
+	Versions []MojangManifestVersion `json:"versions"`
+}
+
+type MojangLatestVersions struct {
+	Release  string `json:"release"`
+	Snapshot string `json:"snapshot"`
+}
+
+type MojangManifestVersion struct {
+	Id          string `json:"id"`
+	Type        string `json:"type"`
+	Url         string `json:"url"`
+	Time        string `json:"time"`
+	ReleaseTime string `json:"releaseTime"`
+	Sha1        string `json:"sha1"`
+}
+
+// MojangVersionMeta is the per-version JSON, only the fields used are decoded
+type MojangVersionMeta struct {
+	Id          string             `json:"id"`
+	Type        string             `json:"type"`
+	JavaVersion MojangJavaVersion  `json:"javaVersion"`
+	PackVersion *MojangPackVersion `json:"pack_version,omitempty"`
+}
+
+type MojangJavaVersion struct {
+	Component    string `json:"component"`
+	MajorVersion int    `json:"majorVersion"`
+}
+
+// MojangPackVersion contains the resource and data pack formats, older
+// versions use a single number for both
+type MojangPackVersion struct {
+	Resource int `json:"resource"`
+	Data     int `json:"data"`
+}
+
+func (p *MojangPackVersion) UnmarshalJSON(b []byte) error {
+	var n int
+	if json.Unmarshal(b, &n) == nil {
+		p.Resource, p.Data = n, n
+		return nil
+	}
+	var v struct {
+		Resource      int `json:"resource"`
+		Data          int `json:"data"`
+		ResourceMajor int `json:"resource_major"`
+		DataMajor     int `json:"data_major"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	p.Resource, p.Data = v.Resource, v.Data
+	if v.ResourceMajor != 0 {
+		p.Resource = v.ResourceMajor
+	}
+	if v.DataMajor != 0 {
+		p.Data = v.DataMajor
+	}
+	return nil
+}
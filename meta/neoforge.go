@@ -3,3 +3,6 @@ package meta
 import "github.com/mrmelon54/mcmodupdater/meta/shared"
 
 type NeoForgeApiMeta shared.MavenMeta
+type NeoForgeLegacyApiMeta shared.MavenMeta
+type NeoGradleMeta shared.MavenMeta
+type ModDevGradleMeta shared.MavenMeta
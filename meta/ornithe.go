@@ -0,0 +1,8 @@
+package meta
+
+import "github.com/mrmelon54/mcmodupdater/meta/shared"
+
+type OrnitheGameMeta []shared.GameVersionMeta
+type OrnitheFeatherMeta []shared.YarnVersionMeta
+type OrnitheLoaderMeta []shared.LoaderVersionMeta
+type OrnitheOslMeta shared.MavenMeta
@@ -0,0 +1,7 @@
+package meta
+
+import "github.com/mrmelon54/mcmodupdater/meta/shared"
+
+type VelocityApiMeta shared.MavenMeta
+type BungeeCordApiMeta shared.MavenMeta
+type WaterfallApiMeta shared.MavenMeta
@@ -1,5 +1,10 @@
 package lib_version
 
+import (
+	"encoding/json"
+	"strings"
+)
+
 type LibVersion struct {
 	Versions  map[string]string   `json:"versions,omitempty"`
 	Libraries map[string]Library  `json:"libraries,omitempty"`
@@ -9,14 +14,74 @@ type LibVersion struct {
 
 type Library struct {
 	Module  string  `json:"module,omitempty"`
+	Group   string  `json:"group,omitempty"`
+	Name    string  `json:"name,omitempty"`
 	Version Version `json:"version,omitempty"`
 }
 
+func (l *Library) UnmarshalJSON(b []byte) error {
+	// string notation "group:artifact:version"
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		if n := strings.LastIndex(s, ":"); n != -1 && strings.Count(s, ":") == 2 {
+			l.Module, l.Version.Value = s[:n], s[n+1:]
+			return nil
+		}
+		l.Module = s
+		return nil
+	}
+	type library Library
+	return json.Unmarshal(b, (*library)(l))
+}
+
+// ModuleId returns the "group:artifact" module of the library
+func (l Library) ModuleId() string {
+	if l.Module == "" && l.Group != "" {
+		return l.Group + ":" + l.Name
+	}
+	return l.Module
+}
+
+// Version is either a plain version string or a reference to the versions table
 type Version struct {
-	Ref string `json:"ref,omitempty"`
+	Ref   string `json:"ref,omitempty"`
+	Value string `json:"-"`
+}
+
+func (v *Version) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '"' {
+		return json.Unmarshal(b, &v.Value)
+	}
+	type version Version
+	return json.Unmarshal(b, (*version)(v))
+}
+
+// Resolve returns the version value, looking up references in the versions table
+func (v Version) Resolve(versions map[string]string) string {
+	if v.Ref != "" {
+		return versions[v.Ref]
+	}
+	return v.Value
 }
 
 type Plugin struct {
-	ID      string `json:"id,omitempty"`
-	Version string `json:"version,omitempty"`
+	ID      string  `json:"id,omitempty"`
+	Version Version `json:"version,omitempty"`
+}
+
+func (p *Plugin) UnmarshalJSON(b []byte) error {
+	// string notation "plugin.id:version"
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		p.ID, p.Version.Value, _ = strings.Cut(s, ":")
+		return nil
+	}
+	type plugin Plugin
+	return json.Unmarshal(b, (*plugin)(p))
 }
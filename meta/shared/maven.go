@@ -2,6 +2,7 @@ package shared
 
 import (
 	"encoding/xml"
+	"github.com/Masterminds/semver/v3"
 	"strings"
 )
 
@@ -39,6 +40,18 @@ func LatestMavenVersion(m MavenMeta, mc string) (string, bool) {
 	return a, a != ""
 }
 
+// LatestPrefixMavenVersion returns the last version starting with the prefix,
+// like "b1.7.3+" or "1.20.4-"
+func LatestPrefixMavenVersion(m MavenMeta, prefix string) (string, bool) {
+	var a string
+	for _, i := range m.Versioning.Versions.Version {
+		if strings.HasPrefix(i, prefix) {
+			a = i
+		}
+	}
+	return a, a != ""
+}
+
 func LatestForgeMavenVersion(m MavenMeta, mc string) (string, bool) {
 	for _, i := range m.Versioning.Versions.Version {
 		if strings.HasPrefix(i, mc+"-") {
@@ -48,15 +61,55 @@ func LatestForgeMavenVersion(m MavenMeta, mc string) (string, bool) {
 	return "", false
 }
 
+// LatestNeoForgeMavenVersion returns the last version using the NeoForge
+// version prefix for the Minecraft version
 func LatestNeoForgeMavenVersion(m MavenMeta, mc string) (string, bool) {
-	after, found := strings.CutPrefix(mc, "1.")
-	if !found {
+	prefix, ok := NeoForgeVersionPrefix(mc)
+	if !ok {
 		return "", false
 	}
+	return LatestPrefixMavenVersion(m, prefix)
+}
+
+// Release channels for versions without a Minecraft version
+const (
+	ChannelStable   = "stable"
+	ChannelBeta     = "beta"
+	ChannelSnapshot = "snapshot"
+)
+
+// InChannel reports whether the pre-release part of a version is allowed by
+// the channel, stable versions are in every channel
+func InChannel(v *semver.Version, channel string) bool {
+	pre := strings.ToLower(v.Prerelease())
+	if pre == "" {
+		return true
+	}
+	switch channel {
+	case ChannelSnapshot:
+		return true
+	case ChannelBeta:
+		for _, i := range []string{"alpha", "beta", "rc", "pre"} {
+			if strings.Contains(pre, i) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// LatestChannelMavenVersion returns the highest version allowed by the channel,
+// versions which aren't valid semver are ignored
+func LatestChannelMavenVersion(m MavenMeta, channel string) (string, bool) {
 	var latest string
+	var latestVer *semver.Version
 	for _, i := range m.Versioning.Versions.Version {
-		if strings.HasPrefix(i, after+".") {
-			latest = i
+		v, err := semver.NewVersion(i)
+		if err != nil || !InChannel(v, channel) {
+			continue
+		}
+		if latestVer == nil || v.GreaterThan(latestVer) {
+			latest, latestVer = i, v
 		}
 	}
 	return latest, latest != ""
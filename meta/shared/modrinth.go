@@ -18,6 +18,9 @@ func (m ModrinthVersionList) FilterGameVersions(gameVersion string) ModrinthVers
 }
 
 func (m ModrinthVersionList) GetLatest() string {
+	if len(m) == 0 {
+		return ""
+	}
 	return slices.MaxFunc(m, func(a, b ModrinthVersion) int {
 		return a.VersionNumber.Compare(b.VersionNumber)
 	}).GetVersion()
@@ -0,0 +1,47 @@
+package shared
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// release versions like 1.21.4 or the year based 26.1
+	neoForgeReleaseRe = regexp.MustCompile(`^(\d+)\.(\d+)(?:\.(\d+))?$`)
+	// weekly snapshots like 24w14a or 25w14craftmine
+	neoForgeSnapshotRe = regexp.MustCompile(`^\d{2}w\d{2}[a-z]+$`)
+)
+
+// NeoForgeVersionPrefix maps a Minecraft version to the prefix of the NeoForge
+// versions built for it:
+//
+//   - 1.21.4 => "21.4.", with 1.21 and 1.21.0 both => "21.0."
+//   - 26.1 and 26.1.1 => "26.1.0." and "26.1.1." for year based versions
+//   - 25w14craftmine => "0.25w14craftmine." for snapshots, pre-releases and
+//     release candidates
+//
+// Versions before 1.20.2 use the net.neoforged:forge artifact and have no prefix.
+func NeoForgeVersionPrefix(mc string) (string, bool) {
+	if neoForgeSnapshotRe.MatchString(mc) || strings.Contains(mc, "-") {
+		return "0." + mc + ".", true
+	}
+	m := neoForgeReleaseRe.FindStringSubmatch(mc)
+	if m == nil {
+		return "", false
+	}
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+	patch := 0
+	if m[3] != "" {
+		patch, _ = strconv.Atoi(m[3])
+	}
+	if major != 1 {
+		// year based versions keep every part
+		return strconv.Itoa(major) + "." + strconv.Itoa(minor) + "." + strconv.Itoa(patch) + ".", true
+	}
+	if minor < 20 || (minor == 20 && patch < 2) {
+		return "", false
+	}
+	return strconv.Itoa(minor) + "." + strconv.Itoa(patch) + ".", true
+}
@@ -0,0 +1,31 @@
+package shared
+
+import "testing"
+
+func TestNeoForgeVersionPrefix(t *testing.T) {
+	for _, i := range []struct {
+		mc     string
+		prefix string
+		ok     bool
+	}{
+		{"1.21", "21.0.", true},
+		{"1.21.0", "21.0.", true},
+		{"1.21.4", "21.4.", true},
+		{"1.20.2", "20.2.", true},
+		{"1.20.1", "", false},
+		{"1.19.4", "", false},
+		{"25w14craftmine", "0.25w14craftmine.", true},
+		{"24w14a", "0.24w14a.", true},
+		{"1.21.5-pre1", "0.1.21.5-pre1.", true},
+		{"1.21.5-rc1", "0.1.21.5-rc1.", true},
+		{"26.1", "26.1.0.", true},
+		{"26.1.1", "26.1.1.", true},
+		{"", "", false},
+		{"latest", "", false},
+	} {
+		prefix, ok := NeoForgeVersionPrefix(i.mc)
+		if prefix != i.prefix || ok != i.ok {
+			t.Errorf("NeoForgeVersionPrefix(%q) = %q, %v; want %q, %v", i.mc, prefix, ok, i.prefix, i.ok)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package mcmodupdater
+
+import (
+	"fmt"
+	"github.com/mrmelon54/mcmodupdater/develop"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+// MultiVersionDir is the folder containing one sub-folder per Minecraft
+// version in Stonecutter and preprocessor projects
+const MultiVersionDir = "versions"
+
+// MultiVersionInfo contains the shared root properties and the properties of
+// each version folder
+type MultiVersionInfo struct {
+	Root     *develop.PlatformVersions
+	Versions []VersionedInfo
+}
+
+type VersionedInfo struct {
+	Dir  string
+	Info *develop.PlatformVersions
+	// Props contains only the properties defined in the version folder
+	Props map[develop.PropVersion]string
+}
+
+// MultiVersionDirs returns the version folders which contain their own
+// properties file, an empty list means this isn't a multi-version project
+func (m *McModUpdater) MultiVersionDirs(tree fs.FS, propsName string) []string {
+	entries, err := fs.ReadDir(tree, MultiVersionDir)
+	if err != nil {
+		return nil
+	}
+	a := make([]string, 0, len(entries))
+	for _, i := range entries {
+		if !i.IsDir() {
+			continue
+		}
+		p := path.Join(MultiVersionDir, i.Name())
+		if _, err := fs.Stat(tree, path.Join(p, propsName)); err == nil {
+			a = append(a, p)
+		}
+	}
+	sort.Strings(a)
+	return a
+}
+
+func (m *McModUpdater) LoadMultiVersionTree(tree fs.StatFS, propsName string) (*MultiVersionInfo, error) {
+	dirs := m.MultiVersionDirs(tree, propsName)
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("no version folders found")
+	}
+
+	platform, err := m.detectPlatform(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	rootVersions := make(map[develop.PropVersion]string)
+	if _, err := fs.Stat(tree, propsName); err == nil {
+		rootVersions, err = platform.ReadVersionFile(tree, propsName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	mapGradleWrapper(tree, rootVersions)
+	mapJavaVersion(tree, rootVersions)
+
+	info := &MultiVersionInfo{
+		Root:     &develop.PlatformVersions{Platform: platform, Versions: rootVersions, SubPlatforms: subPlatforms(platform)},
+		Versions: make([]VersionedInfo, 0, len(dirs)),
+	}
+	err = mapForgePromotion(tree, propsName, info.Root)
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range dirs {
+		sub, err := fs.Sub(tree, dir)
+		if err != nil {
+			return nil, err
+		}
+		props, err := platform.ReadVersionFile(sub, propsName)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", dir, err)
+		}
+
+		// version properties override the shared root properties
+		versions := make(map[develop.PropVersion]string, len(rootVersions)+len(props))
+		for k, v := range rootVersions {
+			versions[k] = v
+		}
+		for k, v := range props {
+			versions[k] = v
+		}
+		if _, ok := props[develop.MinecraftVersion]; !ok {
+			// folders are named after the version, e.g. "1.20.4" or "1.20.4-fabric"
+			mc, _, _ := strings.Cut(path.Base(dir), "-")
+			versions[develop.MinecraftVersion] = mc
+		}
+
+		// the option in the version folder overrides the root option
+		versionInfo := &develop.PlatformVersions{Platform: platform, Versions: versions, SubPlatforms: subPlatforms(platform), ForgePromotion: info.Root.ForgePromotion}
+		err = mapForgePromotion(sub, propsName, versionInfo)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", dir, err)
+		}
+
+		info.Versions = append(info.Versions, VersionedInfo{
+			Dir:   dir,
+			Info:  versionInfo,
+			Props: props,
+		})
+	}
+	return info, nil
+}
+
+// MultiVersionUpdateLists resolves the updates for each version folder against
+// its own Minecraft version. The root list contains the shared properties
+// which aren't overridden, resolved once using the version folder matching the
+// root Minecraft version or the first version folder.
+func (m *McModUpdater) MultiVersionUpdateLists(info *MultiVersionInfo) (VersionUpdateList, []VersionUpdateList) {
+	versions := make([]VersionUpdateList, len(info.Versions))
+	primary := 0
+	for n, i := range info.Versions {
+		versions[n] = m.VersionUpdateList(i.Info)
+		if i.Info.Versions[develop.MinecraftVersion] == info.Root.Versions[develop.MinecraftVersion] {
+			primary = n
+		}
+	}
+
+	root := make(VersionUpdateList, 0, len(info.Root.Versions))
+	if len(versions) == 0 {
+		return root, versions
+	}
+	for _, i := range versions[primary] {
+		if _, ok := info.Root.Versions[i.Property]; !ok {
+			continue
+		}
+		if _, ok := info.Versions[primary].Props[i.Property]; ok {
+			// the root value is ignored by this version folder
+			i = VersionUpdateItem{i.Property, info.Root.Versions[i.Property], ""}
+		}
+		root = append(root, i)
+	}
+
+	// only keep the properties defined in each version folder
+	for n, v := range versions {
+		a := make(VersionUpdateList, 0, len(v))
+		for _, i := range v {
+			if _, ok := info.Versions[n].Props[i.Property]; ok || i.Property == develop.MinecraftVersion {
+				a = append(a, i)
+			}
+		}
+		versions[n] = a
+	}
+	return root, versions
+}
+
+// MultiVersionFileUpdates returns the file updates for the project root using
+// the root list and for each version folder using the targets of that folder,
+// so the mod metadata and build scripts in a folder follow its own Minecraft
+// version
+func (m *McModUpdater) MultiVersionFileUpdates(tree fs.FS, info *MultiVersionInfo, root VersionUpdateList) ([]FileUpdate, error) {
+	a := m.FileUpdates(tree, root)
+	for _, i := range info.Versions {
+		sub, err := fs.Sub(tree, i.Dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, j := range m.FileUpdates(sub, m.VersionUpdateList(i.Info)) {
+			a = append(a, FileUpdate{path.Join(i.Dir, j.Name), j.Update})
+		}
+	}
+	return a, nil
+}
@@ -0,0 +1,97 @@
+package mcmodupdater
+
+import (
+	"github.com/mrmelon54/mcmodupdater/develop"
+	"github.com/mrmelon54/mcmodupdater/develop/dev"
+	"io/fs"
+	"strings"
+)
+
+// scanSkipDirs are never searched for projects
+var scanSkipDirs = map[string]bool{
+	"build":        true,
+	"out":          true,
+	"run":          true,
+	"bin":          true,
+	"node_modules": true,
+}
+
+// IsProject reports whether LoadTree would find a platform for the tree
+func (m *McModUpdater) IsProject(tree fs.FS) bool {
+	if m.platArch.ValidTree(tree) {
+		return true
+	}
+	for _, i := range m.platforms {
+		if i.ValidTree(tree) {
+			return true
+		}
+	}
+	return false
+}
+
+// ScanProjects walks the tree and returns the path of every project found,
+// descent stops at project roots so sub-projects are never listed separately
+func (m *McModUpdater) ScanProjects(root fs.FS) ([]string, error) {
+	a := make([]string, 0)
+	err := fs.WalkDir(root, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if p != "." && (strings.HasPrefix(d.Name(), ".") || scanSkipDirs[d.Name()]) {
+			return fs.SkipDir
+		}
+		sub, err := fs.Sub(root, p)
+		if err != nil {
+			return err
+		}
+		if m.IsProject(sub) {
+			a = append(a, p)
+			return fs.SkipDir
+		}
+		return nil
+	})
+	return a, err
+}
+
+// Fetch runs the fetch calls for the platform, and the sub-platforms for
+// Architectury, each platform is only fetched once per McModUpdater
+func (m *McModUpdater) Fetch(platform develop.Develop) error {
+	if arc, ok := platform.(*dev.Architectury); ok {
+		// fetch architectury specific caches first
+		err := m.fetchOnce(arc)
+		if err != nil {
+			return err
+		}
+
+		// fetch sub-platform caches
+		for _, i := range arc.SubPlatformList() {
+			err := m.fetchOnce(arc.SubPlatforms[i])
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return m.fetchOnce(platform)
+}
+
+func (m *McModUpdater) fetchOnce(platform develop.Develop) error {
+	return m.fetchCallsOnce(platform.Platform(), platform.FetchCalls())
+}
+
+func (m *McModUpdater) fetchCallsOnce(p develop.DevPlatform, calls []develop.DevFetch) error {
+	if m.fetched[p] {
+		return nil
+	}
+	for _, i := range calls {
+		err := i.Call()
+		if err != nil {
+			return err
+		}
+	}
+	m.fetched[p] = true
+	return nil
+}
@@ -0,0 +1,52 @@
+package mcmodupdater
+
+import (
+	"github.com/mrmelon54/mcmodupdater/develop"
+)
+
+// RejectedVersion is a Minecraft version which is missing a release of at
+// least one dependency
+type RejectedVersion struct {
+	Minecraft string
+	Blocking  []develop.PropVersion
+}
+
+// BlockingDependencies returns the properties in the update list which don't
+// have a release for the Minecraft version. Properties which can't be resolved
+// for the current Minecraft version of the project are ignored.
+func (m *McModUpdater) BlockingDependencies(info *develop.PlatformVersions, list VersionUpdateList, mcVersion string) []develop.PropVersion {
+	current := info.Versions[develop.MinecraftVersion]
+	a := make([]develop.PropVersion, 0)
+	for _, i := range list {
+		if i.Property == develop.ModVersion || i.Property == develop.MinecraftVersion {
+			continue
+		}
+		if _, ok := info.Platform.LatestVersion(i.Property, current); !ok {
+			continue
+		}
+		if _, ok := info.Platform.LatestVersion(i.Property, mcVersion); !ok {
+			a = append(a, i.Property)
+		}
+	}
+	return a
+}
+
+// FindTargetVersion checks each candidate, newest first, and returns the first
+// Minecraft version where every dependency has a release along with the
+// rejected newer versions
+func (m *McModUpdater) FindTargetVersion(info *develop.PlatformVersions, candidates []string) (string, []RejectedVersion) {
+	// the properties don't depend on the candidate so the list is only built once
+	list := m.VersionUpdateList(info)
+	rejected := make([]RejectedVersion, 0)
+	for _, mc := range candidates {
+		blocking := m.BlockingDependencies(info, list, mc)
+		if len(blocking) == 0 {
+			return mc, rejected
+		}
+		rejected = append(rejected, RejectedVersion{mc, blocking})
+		if mc == info.Versions[develop.MinecraftVersion] {
+			break
+		}
+	}
+	return "", rejected
+}